@@ -21,6 +21,9 @@ const (
 	acceptEncodingHeader  = "Accept-Encoding"
 )
 
+// Maximum size of an event payload pushed to the exporter
+const maxEventSize = 1 << 20
+
 var gzipPool = sync.Pool{
 	New: func() interface{} {
 		return gzip.NewWriter(nil)
@@ -65,8 +68,34 @@ func getMetricGroupName(val metrics.MetricGroupType) (string, error) {
 		return "storage", nil
 	case metrics.MetricGroupTypeMemory:
 		return "memory", nil
+	case metrics.MetricGroupTypeLclog:
+		return "lclog", nil
+	case metrics.MetricGroupTypeChassis:
+		return "chassis", nil
+	case metrics.MetricGroupTypeSecurity:
+		return "security", nil
+	case metrics.MetricGroupTypeBoot:
+		return "boot", nil
+	case metrics.MetricGroupTypeBios:
+		return "bios", nil
+	case metrics.MetricGroupTypeCertificates:
+		return "certificates", nil
+	case metrics.MetricGroupTypeAccounts:
+		return "accounts", nil
+	case metrics.MetricGroupTypeTelemetry:
+		return "telemetry", nil
+	case metrics.MetricGroupTypeEvents:
+		return "events", nil
+	case metrics.MetricGroupTypeCustom:
+		return "custom", nil
+	case metrics.MetricGroupTypeOem:
+		return "oem", nil
+	case metrics.MetricGroupTypeAccelerators:
+		return "accelerators", nil
+	case metrics.MetricGroupTypeInventory:
+		return "inventory", nil
 	default:
-		return "", fmt.Errorf("Unrecognized metric group type: '%s'", val)
+		return "", fmt.Errorf("Unrecognized metric group type: %d", val)
 	}
 }
 
@@ -90,6 +119,32 @@ func getMetricGroupParam(req *http.Request) (metrics.MetricGroupType, error) {
 		return metrics.MetricGroupTypeStorage, nil
 	case "memory":
 		return metrics.MetricGroupTypeMemory, nil
+	case "lclog":
+		return metrics.MetricGroupTypeLclog, nil
+	case "chassis":
+		return metrics.MetricGroupTypeChassis, nil
+	case "security":
+		return metrics.MetricGroupTypeSecurity, nil
+	case "boot":
+		return metrics.MetricGroupTypeBoot, nil
+	case "bios":
+		return metrics.MetricGroupTypeBios, nil
+	case "certificates":
+		return metrics.MetricGroupTypeCertificates, nil
+	case "accounts":
+		return metrics.MetricGroupTypeAccounts, nil
+	case "telemetry":
+		return metrics.MetricGroupTypeTelemetry, nil
+	case "events":
+		return metrics.MetricGroupTypeEvents, nil
+	case "custom":
+		return metrics.MetricGroupTypeCustom, nil
+	case "oem":
+		return metrics.MetricGroupTypeOem, nil
+	case "accelerators":
+		return metrics.MetricGroupTypeAccelerators, nil
+	case "inventory":
+		return metrics.MetricGroupTypeInventory, nil
 	default:
 		return metrics.MetricGroupTypeAny, fmt.Errorf("Unrecognized value for query parameter 'metric': '%s'", metric)
 	}
@@ -99,6 +154,12 @@ func HealthHandler(rsp http.ResponseWriter, req *http.Request) {
 	// just return a simple 200 for now
 }
 
+func ReadyHandler(rsp http.ResponseWriter, req *http.Request) {
+	if !collector.Ready() {
+		http.Error(rsp, "Warm-up in progress", http.StatusServiceUnavailable)
+	}
+}
+
 func ResetHandler(rsp http.ResponseWriter, req *http.Request) {
 	target, err := getTargetParam(req)
 	if err != nil {
@@ -117,6 +178,33 @@ func ResetHandler(rsp http.ResponseWriter, req *http.Request) {
 	collector.Reset(target, metricGroup)
 }
 
+func EventsHandler(rsp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(rsp, "Only POST requests are accepted", http.StatusMethodNotAllowed)
+		return
+	}
+
+	target := req.URL.Query().Get("target")
+	body := http.MaxBytesReader(rsp, req.Body, maxEventSize)
+
+	logging.Debugf("Handling event from %s", req.RemoteAddr)
+
+	err := collector.HandleEvents(target, body)
+	if err != nil {
+		status := http.StatusBadRequest
+		switch err {
+		case collector.ErrInvalidSecret:
+			status = http.StatusForbidden
+		case collector.ErrUnknownTarget:
+			status = http.StatusNotFound
+		}
+
+		errorMsg := fmt.Sprintf("Error handling event from %s", req.RemoteAddr)
+		logging.Error(err, errorMsg)
+		http.Error(rsp, errorMsg, status)
+		return
+	}
+}
 
 func MetricsHandler(rsp http.ResponseWriter, req *http.Request) {
 	target, err := getTargetParam(req)
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/mrlhansen/idrac_exporter/internal/collector"
 	"github.com/mrlhansen/idrac_exporter/internal/config"
 	"github.com/mrlhansen/idrac_exporter/internal/logging"
+	"github.com/mrlhansen/idrac_exporter/internal/metrics"
 	"github.com/mrlhansen/idrac_exporter/internal/version"
 )
 
@@ -30,9 +36,22 @@ func main() {
 		logging.Info("Verbose mode enabled")
 	}
 
+	// Catch metric definitions that cannot be registered before serving
+	if _, err := collector.NewCollector(metrics.MetricGroupTypeAny); err != nil {
+		logging.Fatal(err, "Error registering metrics")
+	}
+
+	// Restoring the state adds every target ever scraped to the hosts, so
+	// the warm-up targets are taken from the configuration file first
+	targets := collector.WarmupTargets()
+	collector.LoadState()
+	collector.Warmup(targets)
+
 	http.HandleFunc("/metrics", MetricsHandler)
 	http.HandleFunc("/health", HealthHandler)
+	http.HandleFunc("/ready", ReadyHandler)
 	http.HandleFunc("/reset", ResetHandler)
+	http.HandleFunc("/events", EventsHandler)
 	bind := fmt.Sprintf("%s:%d", config.Config.Address, config.Config.Port)
 
 	logging.Infof("Build information: version=%s revision=%s", version.Version, version.Revision)
@@ -42,8 +61,25 @@ func main() {
 		logging.Infof("Running in single host mode. Only responding to requests for '%s'", config.Config.SingleHost)
 	}
 
-	err := http.ListenAndServe(bind, nil)
-	if err != nil {
+	server := &http.Server{Addr: bind}
+	done := make(chan struct{})
+
+	// Remove event subscriptions from the targets before exiting
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+
+		logging.Info("Shutting down")
+		collector.Shutdown()
+		server.Shutdown(context.Background())
+		close(done)
+	}()
+
+	err := server.ListenAndServe()
+	if err != http.ErrServerClosed {
 		logging.Fatal(err)
 	}
+
+	<-done
 }
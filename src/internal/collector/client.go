@@ -1,8 +1,11 @@
 package collector
 
 import (
+	"bytes"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"github.com/mrlhansen/idrac_exporter/internal/config"
@@ -10,6 +13,8 @@ import (
 	"github.com/mrlhansen/idrac_exporter/internal/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"net/http"
+	"path"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,27 +22,97 @@ import (
 
 const redfishRootPath = "/redfish/v1"
 
+// Maximum number of lifecycle log entries kept per target
+const lclogMaxEntries = 100
+
+// Vendor default accounts (Dell, HPE and Lenovo)
+var defaultAccounts = []string{"root", "Administrator", "USERID"}
+
+// endpoints holds everything found during discovery. It is replaced as a
+// whole under endpointsMu when the endpoints are rediscovered.
+type endpoints struct {
+	redfishVersion  string
+	systemPath      string
+	chassisPath     string
+	thermalPath     string
+	powerPath       string
+	environmentPath string
+	storagePath     string
+	memoryPath      string
+	processorsPath  string
+	certServicePath string
+	accountsPath    string
+	telemetryPath   string
+	eventsPath      string
+
+	// Vendor detected during discovery and the matching OEM plugin
+	vendor          string
+	oem             oemPlugin
+}
+
 type Client struct {
 	configMu        sync.Mutex
 	requestMu       sync.Mutex
+	endpointsMu     sync.RWMutex
 	
 	hostname        string
 	basicAuth       string
 	httpClient      *http.Client
-	systemPath      string
-	thermalPath     string
-	powerPath       string
-	storagePath     string
-	memoryPath      string
+
+	endpoints
+
+	// Event subscription registered by the exporter
+	subscriptionPath string
+	streaming        bool
+
+	// Leaf certificate presented by the BMC in the last TLS handshake
+	peerCertificate *x509.Certificate
+
+	// Expected boot order from the host configuration
+	bootOrder       []string
 
 	foundEndpoints  bool
 
+	// Set when the endpoints were restored from the state file
+	restored        bool
+
+	// Rediscovery of the endpoints, stale and missing are guarded by requestMu
+	versionChecked  time.Time
+	stale           bool
+	missing         map[string]bool
+	rediscoveries   uint64
+
+	// Energy consumed by the target, integrated from power readings
+	energy          energyMeter
+
+	// Last known serial numbers of replaceable components
+	inventory       inventoryState
+
 	retries         uint
+
+	// Lifecycle log entries newer than the cursor are fetched on each refresh
+	lclogMu         sync.Mutex
+	lclogCursor     time.Time
+	lclogEntries    []LogEntry
 }
 
 var clientsMu sync.Mutex
 var clients = map[string]*Client{}
 
+// getClients returns a copy of the clients, so that clientsMu is not held while
+// waiting for a client that is busy with discovery
+func getClients() map[string]*Client {
+	clientsMu.Lock()
+	defer clientsMu.Unlock()
+
+	c := make(map[string]*Client, len(clients))
+	for target, client := range clients {
+		c[target] = client
+	}
+
+	return c
+}
+
 func newHttpClient() *http.Client {
 	return &http.Client{
 		Transport: &http.Transport{
@@ -47,17 +122,21 @@ func newHttpClient() *http.Client {
 	}
 }
 
+func newClient(target string) *Client {
+	hostConfig := config.Config.GetHostCfg(target)
+	return &Client{
+		hostname:   hostConfig.Hostname,
+		basicAuth:  hostConfig.Token,
+		httpClient: newHttpClient(),
+		bootOrder:  hostConfig.BootOrder,
+	}
+}
+
 func GetClient(target string) (*Client, error) {
 	clientsMu.Lock()
 	client, ok := clients[target]
 	if !ok {
-		hostConfig := config.Config.GetHostCfg(target)
-		client = &Client{
-			hostname:   hostConfig.Hostname,
-			basicAuth:  hostConfig.Token,
-			httpClient: newHttpClient(),
-		}
-		
+		client = newClient(target)
 		clients[target] = client
 	}
 	clientsMu.Unlock()
@@ -74,7 +153,7 @@ func GetClient(target string) (*Client, error) {
 
 		logging.Debugf("Finding endpoints for target '%s'...", target)
 
-		if err := client.findAllEndpoints(); err != nil {
+		if err := client.discover(); err != nil {
 			client.retries++
 
 			logging.Errorf(err, "Error finding endpoints for target '%s'", target)
@@ -86,11 +165,42 @@ func GetClient(target string) (*Client, error) {
 		
 		client.retries = 0
 		client.foundEndpoints = true
+		client.start(target)
+	} else if client.restored {
+		// The OEM plugin is not part of the restored state. It is discovered
+		// again on the next scrape when the target cannot be reached.
+		if err := client.discoverOem(); err != nil {
+			logging.Errorf(err, "Error discovering %s OEM endpoints for target '%s'", client.vendor, target)
+		} else {
+			client.restored = false
+		}
+
+		client.start(target)
 	}
 
 	return client, nil
 }
 
+// start registers event subscriptions and starts the background tasks once
+// the endpoints of the target are known
+func (client *Client) start(target string) {
+	if config.Config.Events.Subscribe {
+		if err := client.subscribe(); err != nil {
+			logging.Errorf(err, "Error registering event subscription for target '%s'", target)
+		}
+	}
+
+	if config.Config.Events.Stream {
+		if err := client.startStream(); err != nil {
+			logging.Errorf(err, "Error opening event stream for target '%s'", target)
+		}
+	}
+
+	if config.Config.Collect.Power {
+		client.startPowerSampler()
+	}
+}
+
 func (client *Client) findAllEndpoints() error {
 	var root V1Response
 	var group GroupResponse
@@ -104,12 +214,23 @@ func (client *Client) findAllEndpoints() error {
 		return err
 	}
 
+	client.redfishVersion = root.RedfishVersion
+	client.certServicePath = root.CertificateService.OdataId
+	client.accountsPath = root.AccountService.OdataId
+	client.telemetryPath = root.TelemetryService.OdataId
+	client.eventsPath = root.EventService.OdataId
+
 	// System
 	err = client.redfishGet(root.Systems.OdataId, &group)
 	if err != nil {
 		return err
 	}
 
+	// Collections may be empty while the BMC is starting
+	if len(group.Members) == 0 {
+		return fmt.Errorf("no systems found")
+	}
+
 	client.systemPath = group.Members[0].OdataId
 
 	// Chassis
@@ -118,8 +239,14 @@ func (client *Client) findAllEndpoints() error {
 		return err
 	}
 
+	if len(group.Members) == 0 {
+		return fmt.Errorf("no chassis found")
+	}
+
+	client.chassisPath = group.Members[0].OdataId
+
 	// Thermal and Power
-	err = client.redfishGet(group.Members[0].OdataId, &chassis)
+	err = client.redfishGet(client.chassisPath, &chassis)
 	if err != nil {
 		return err
 	}
@@ -131,12 +258,100 @@ func (client *Client) findAllEndpoints() error {
 
 	client.storagePath = system.Storage.OdataId
 	client.memoryPath = system.Memory.OdataId
+	client.processorsPath = system.Processors.OdataId
 	client.thermalPath = chassis.Thermal.OdataId
 	client.powerPath = chassis.Power.OdataId
+	client.environmentPath = chassis.EnvironmentMetrics.OdataId
+
+	// Vendor plugin
+	client.vendor = detectVendor(&root)
+	// A failing plugin must not prevent the generic metrics
+	client.oem, err = client.newOemPlugin(&root)
+	if err != nil {
+		logging.Errorf(err, "Error discovering %s OEM endpoints for target '%s'", client.vendor, client.hostname)
+	}
+
+	return nil
+}
+
+// discover runs the discovery on a separate client and swaps in the endpoints
+// found, so refreshers running at the same time never see a partially updated
+// set of endpoints
+func (client *Client) discover() error {
+	d := &Client{
+		hostname:   client.hostname,
+		basicAuth:  client.basicAuth,
+		httpClient: client.httpClient,
+	}
+
+	err := d.findAllEndpoints()
+	if err != nil {
+		return err
+	}
+
+	client.endpointsMu.Lock()
+	defer client.endpointsMu.Unlock()
+
+	client.endpoints = d.endpoints
+	client.versionChecked = time.Now()
+
+	client.requestMu.Lock()
+	defer client.requestMu.Unlock()
+
+	// Endpoints that are still missing after a rediscovery are not going to
+	// appear by themselves, so they do not trigger another one
+	for path := range client.missing {
+		if !client.isEndpoint(path) {
+			delete(client.missing, path)
+		}
+	}
+	client.stale = false
+
+	if d.peerCertificate != nil {
+		client.peerCertificate = d.peerCertificate
+	}
+
+	return nil
+}
+
+// discoverOem sets up the OEM plugin of a client restored from the state file
+func (client *Client) discoverOem() error {
+	var root V1Response
+
+	err := client.redfishGet(redfishRootPath, &root)
+	if err != nil {
+		return err
+	}
+
+	plugin, err := client.newOemPlugin(&root)
+	if err != nil {
+		return err
+	}
+
+	client.endpointsMu.Lock()
+	client.oem = plugin
+	client.endpointsMu.Unlock()
 
 	return nil
 }
 
+// newOemPlugin returns the plugin for the detected vendor, or nil when there is
+// no plugin for the vendor
+func (client *Client) newOemPlugin(root *V1Response) (oemPlugin, error) {
+	newPlugin, ok := oemPlugins[client.vendor]
+	if !ok {
+		return nil, nil
+	}
+
+	plugin := newPlugin()
+	err := plugin.discover(client, root)
+	if err != nil {
+		return nil, err
+	}
+
+	return plugin, nil
+}
+
 func (client *Client) RefreshSensors(mc *metrics.SensorsMetricGroup, ch chan<- prometheus.Metric) error {
 	var resp ThermalResponse
 
@@ -197,160 +412,1169 @@ func (client *Client) RefreshSystem(mc *metrics.SystemMetricGroup, ch chan<- pro
 	return nil
 }
 
-func (client *Client) RefreshPower(mc *metrics.PowerMetricGroup, ch chan<- prometheus.Metric) error {
-	var resp PowerResponse
+func (client *Client) RefreshChassis(mc *metrics.ChassisMetricGroup, ch chan<- prometheus.Metric) error {
+	var resp ChassisResponse
 
-	err := client.redfishGet(client.powerPath, &resp)
+	err := client.redfishGet(client.chassisPath, &resp)
 	if err != nil {
 		return err
 	}
 
-	for i, psu := range resp.PowerSupplies {
-		if psu.Status.State != StateEnabled {
-			continue
-		}
+	ch <- mc.NewChassisHealth(resp.Status.Health)
+	ch <- mc.NewChassisHealthRollup(resp.Status.HealthRollup)
+	ch <- mc.NewChassisPowerOn(resp.PowerState)
+	ch <- mc.NewChassisInfo(resp.AssetTag, resp.ChassisType, resp.EnvironmentalClass, resp.Manufacturer, resp.Model, resp.PartNumber, resp.SerialNumber, resp.SKU)
 
-		id := strconv.Itoa(i)
-		ch <- mc.NewPowerSupplyInputWatts(psu.PowerInputWatts, id)
-		ch <- mc.NewPowerSupplyInputVoltage(psu.LineInputVoltage, id)
-		ch <- mc.NewPowerSupplyOutputWatts(psu.GetOutputPower(), id)
-		ch <- mc.NewPowerSupplyCapacityWatts(psu.PowerCapacityWatts, id)
-		ch <- mc.NewPowerSupplyEfficiencyPercent(psu.EfficiencyPercent, id)
+	if resp.PhysicalSecurity != nil {
+		ch <- mc.NewChassisIntrusion(resp.PhysicalSecurity.IntrusionSensor)
 	}
 
-	for i, pc := range resp.PowerControl {
-		id := strconv.Itoa(i)
-		ch <- mc.NewPowerControlConsumedWatts(pc.PowerConsumedWatts, id, pc.Name)
-		ch <- mc.NewPowerControlCapacityWatts(pc.PowerCapacityWatts, id, pc.Name)
+	if resp.Location != nil {
+		loc := resp.Location
+		ch <- mc.NewChassisLocationInfo(loc.Placement.Rack, loc.Placement.Row, loc.PostalAddress.Building, loc.PostalAddress.Room)
+	}
 
-		if pc.PowerMetrics == nil {
+	return nil
+}
+
+func (client *Client) RefreshSecurity(mc *metrics.SecurityMetricGroup, ch chan<- prometheus.Metric) error {
+	var resp SystemResponse
+
+	err := client.redfishGet(client.systemPath, &resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.SecureBoot.OdataId != "" {
+		var sb SecureBoot
+
+		err = client.redfishGet(resp.SecureBoot.OdataId, &sb)
+		if err != nil {
+			return err
+		}
+
+		ch <- mc.NewSecureBootEnabled(sb.SecureBootEnable, sb.SecureBootMode, sb.SecureBootCurrentBoot)
+	}
+
+	present := false
+	for i, tpm := range resp.TrustedModules {
+		if tpm.Status.State == StateAbsent {
 			continue
 		}
 
-		pm := pc.PowerMetrics
-		ch <- mc.NewPowerControlMinConsumedWatts(pm.MinConsumedWatts, id, pc.Name)
-		ch <- mc.NewPowerControlMaxConsumedWatts(pm.MaxConsumedWatts, id, pc.Name)
-		ch <- mc.NewPowerControlAvgConsumedWatts(pm.AverageConsumedWatts, id, pc.Name)
-		ch <- mc.NewPowerControlInterval(pm.IntervalInMinutes, id, pc.Name)
+		present = true
+		ch <- mc.NewTpmInfo(strconv.Itoa(i), tpm.InterfaceType, tpm.FirmwareVersion, tpm.Status.State)
+	}
+	ch <- mc.NewTpmPresent(present)
+
+	if resp.HostWatchdogTimer != nil {
+		ch <- mc.NewHostWatchdogEnabled(resp.HostWatchdogTimer.FunctionEnabled, resp.HostWatchdogTimer.TimeoutAction)
 	}
 
 	return nil
 }
 
-func (client *Client) RefreshIdracSel(mc *metrics.IdracSelMetricGroup, ch chan<- prometheus.Metric) error {
-	var resp IdracSelResponse
+func (client *Client) RefreshBoot(mc *metrics.BootMetricGroup, ch chan<- prometheus.Metric) error {
+	var resp SystemResponse
 
-	err := client.redfishGet(redfishRootPath+"/Managers/iDRAC.Embedded.1/Logs/Sel", &resp)
+	err := client.redfishGet(client.systemPath, &resp)
 	if err != nil {
 		return err
 	}
 
-	for _, e := range resp.Members {
-		st := string(e.SensorType)
-		if st == "" {
-			st = "Unknown"
+	if resp.Boot == nil {
+		return nil
+	}
+
+	boot := resp.Boot
+	ch <- mc.NewBootOrderInfo(boot.BootOrder)
+	ch <- mc.NewBootSourceOverrideInfo(boot.BootSourceOverrideEnabled, boot.BootSourceOverrideTarget, boot.BootSourceOverrideMode)
+
+	// The boot order is compliant when it starts with the expected entries
+	if len(client.bootOrder) > 0 {
+		compliant := len(boot.BootOrder) >= len(client.bootOrder)
+		for i := 0; compliant && i < len(client.bootOrder); i++ {
+			compliant = boot.BootOrder[i] == client.bootOrder[i]
 		}
-		ch <- mc.NewSelEntry(e.Id, e.Message, st, e.Severity, e.Created)
+		ch <- mc.NewBootOrderCompliant(compliant)
 	}
 
 	return nil
 }
 
-func (client *Client) RefreshStorage(mc *metrics.StorageMetricGroup, ch chan<- prometheus.Metric) error {
-	var group GroupResponse
-	var controller StorageController
-	var d Drive
+func (client *Client) RefreshBios(mc *metrics.BiosMetricGroup, ch chan<- prometheus.Metric) error {
+	var system SystemResponse
+	var resp BiosResponse
 
-	err := client.redfishGet(client.storagePath, &group)
+	err := client.redfishGet(client.systemPath, &system)
 	if err != nil {
 		return err
 	}
 
-	for _, c := range group.Members {
-		err = client.redfishGet(c.OdataId, &controller)
-		if err != nil {
-			return err
+	err = client.redfishGet(system.Bios.OdataId, &resp)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range config.Config.BiosAttributes {
+		value, ok := resp.Attributes[name]
+		if !ok {
+			continue
 		}
+		ch <- mc.NewBiosAttribute(name, fmt.Sprint(value))
+	}
 
-		for _, drive := range controller.Drives {
-			err = client.redfishGet(drive.OdataId, &d)
-			if err != nil {
-				return err
-			}
+	if resp.Settings == nil || resp.Settings.SettingsObject.OdataId == "" {
+		return nil
+	}
 
-			ch <- mc.NewDriveInfo(d.Id, d.Name, d.Manufacturer, d.Model, d.SerialNumber, d.MediaType, d.Protocol, d.GetSlot())
-			ch <- mc.NewDriveHealth(d.Id, d.Status.Health)
-			ch <- mc.NewDriveCapacity(d.Id, d.CapacityBytes)
+	var settings BiosResponse
+
+	err = client.redfishGet(resp.Settings.SettingsObject.OdataId, &settings)
+	if err != nil {
+		return err
+	}
+
+	// Some systems return all attributes in the settings object, while others
+	// only return the attributes that are pending
+	pending := 0
+	for name, value := range settings.Attributes {
+		if fmt.Sprint(resp.Attributes[name]) != fmt.Sprint(value) {
+			pending++
 		}
 	}
+	ch <- mc.NewBiosPendingChanges(pending)
 
 	return nil
 }
 
-func (client *Client) RefreshMemory(mc *metrics.MemoryMetricGroup, ch chan<- prometheus.Metric) error {
-	var group GroupResponse
-	var m Memory
+func (client *Client) RefreshCertificates(mc *metrics.CertificatesMetricGroup, ch chan<- prometheus.Metric) error {
+	var service CertificateServiceResponse
+	var locations CertificateLocationsResponse
 
-	err := client.redfishGet(client.memoryPath, &group)
+	// The certificate from the TLS handshake is always available, because
+	// at least one request has been made during endpoint discovery
+	client.requestMu.Lock()
+	cert := client.peerCertificate
+	client.requestMu.Unlock()
+
+	if cert != nil {
+		ch <- mc.NewCertificateExpiry(cert.Subject.CommonName, cert.Issuer.CommonName, "tls", cert.NotAfter)
+	}
+
+	if client.certServicePath == "" {
+		return nil
+	}
+
+	err := client.redfishGet(client.certServicePath, &service)
 	if err != nil {
 		return err
 	}
 
-	for _, c := range group.Members {
-		err = client.redfishGet(c.OdataId, &m)
+	if service.CertificateLocations.OdataId == "" {
+		return nil
+	}
+
+	err = client.redfishGet(service.CertificateLocations.OdataId, &locations)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range locations.Links.Certificates {
+		var certificate Certificate
+
+		err = client.redfishGet(c.OdataId, &certificate)
 		if err != nil {
 			return err
 		}
 
-		if m.Status.State == StateAbsent {
-			continue
-		}
-
-		ch <- mc.NewMemoryModuleInfo(m.Id, m.Name, m.Manufacturer, m.MemoryDeviceType, m.SerialNumber, m.ErrorCorrection, m.RankCount)
-		ch <- mc.NewMemoryModuleHealth(m.Id, m.Status.Health)
-		ch <- mc.NewMemoryModuleCapacity(m.Id, m.CapacityMiB * 1048576)
-		ch <- mc.NewMemoryModuleSpeed(m.Id, m.OperatingSpeedMhz)
+		ch <- mc.NewCertificateExpiry(certificate.Subject.CommonName, certificate.Issuer.CommonName, c.OdataId, certificate.ValidNotAfter)
 	}
 
 	return nil
 }
 
-func (client *Client) redfishGet(path string, res interface{}) error {
-	
-	client.requestMu.Lock()
-	defer client.requestMu.Unlock()
-
-	url := "https://" + client.hostname + path
+func (client *Client) RefreshAccounts(mc *metrics.AccountsMetricGroup, ch chan<- prometheus.Metric) error {
+	var service AccountServiceResponse
+	var group GroupResponse
 
-	req, err := http.NewRequest("GET", url, nil)
+	err := client.redfishGet(client.accountsPath, &service)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Add("Authorization", "Basic " + client.basicAuth)
-	req.Header.Add("Accept", "application/json")
-
-	logging.Debugf("Querying url %q", url)
+	if service.LDAP != nil {
+		ch <- mc.NewAccountsDirectoryService("ldap", service.LDAP.ServiceEnabled)
+	}
 
-	resp, err := client.httpClient.Do(req)
-	if resp != nil {
-		defer resp.Body.Close()
+	if service.ActiveDirectory != nil {
+		ch <- mc.NewAccountsDirectoryService("active_directory", service.ActiveDirectory.ServiceEnabled)
 	}
+
+	err = client.redfishGet(service.Accounts.OdataId, &group)
 	if err != nil {
-		logging.Errorf(err, "Failed to query url %q: %v")
 		return err
 	}
 
-	if resp.StatusCode != 200 {
-		logging.Debugf("Query to url %q returned unexpected status code: %d (%s)", url, resp.StatusCode, resp.Status)
-		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	roles := map[string]int{}
+	users := map[string]bool{}
+
+	for _, c := range group.Members {
+		var account ManagerAccount
+
+		err = client.redfishGet(c.OdataId, &account)
+		if err != nil {
+			return err
+		}
+
+		// Unused account slots have no user name
+		if account.UserName == "" {
+			continue
+		}
+
+		users[account.UserName] = account.Enabled
+
+		if !account.Enabled {
+			continue
+		}
+
+		roles[account.RoleId]++
+
+		if account.PasswordExpiration != "" {
+			expiry, err := time.Parse(time.RFC3339, account.PasswordExpiration)
+			if err == nil {
+				ch <- mc.NewAccountPasswordExpiry(account.UserName, expiry)
+			}
+		}
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(res)
+	for role, count := range roles {
+		ch <- mc.NewAccountsEnabled(role, count)
+	}
+
+	for _, username := range defaultAccounts {
+		enabled, ok := users[username]
+		if ok {
+			ch <- mc.NewAccountsDefaultEnabled(username, enabled)
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshTelemetry(mc *metrics.TelemetryMetricGroup, ch chan<- prometheus.Metric) error {
+	var service TelemetryServiceResponse
+
+	if client.telemetryPath == "" {
+		return nil
+	}
+
+	err := client.redfishGet(client.telemetryPath, &service)
 	if err != nil {
-		logging.Debugf("Error decoding response from url %q: %v", url, err)
 		return err
 	}
 
-	return nil
+	for _, id := range config.Config.TelemetryReports {
+		var report MetricReport
+
+		// Reports depend on the licence and firmware of the target, so
+		// missing reports are skipped
+		err = client.redfishGet(service.MetricReports.OdataId+"/"+id, &report)
+		if errors.Is(err, errNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		// Reports may contain several readings of the same metric, in which
+		// case only the latest reading is exported
+		type key struct {
+			metric   string
+			property string
+		}
+
+		latest := map[key]int{}
+		for i, v := range report.MetricValues {
+			k := key{v.MetricId, v.MetricProperty}
+			j, ok := latest[k]
+			if !ok || v.Timestamp.After(report.MetricValues[j].Timestamp) {
+				latest[k] = i
+			}
+		}
+
+		for k, i := range latest {
+			v := report.MetricValues[i]
+			value, err := strconv.ParseFloat(v.MetricValue, 64)
+			if err != nil {
+				continue
+			}
+			ch <- mc.NewTelemetryMetricValue(id, k.metric, k.property, value, v.Timestamp)
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshEvents(mc *metrics.EventsMetricGroup, ch chan<- prometheus.Metric) error {
+	store := getEventStore(client.hostname)
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	for k, count := range store.counts {
+		ch <- mc.NewEventsTotal(client.hostname, k.severity, k.messageId, count)
+	}
+
+	for _, e := range store.recent {
+		ch <- mc.NewEventEntry(strconv.FormatUint(e.seq, 10), e.Message, e.MessageId, e.GetSeverity(), e.GetTimestamp())
+	}
+
+	for resource, health := range store.health {
+		ch <- mc.NewEventsResourceHealth(resource, health)
+	}
+
+	if store.streaming {
+		ch <- mc.NewEventsStreamConnected(store.connected)
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshCustom(mc *metrics.CustomMetricGroup, ch chan<- prometheus.Metric) error {
+	replacer := strings.NewReplacer(
+		"{system}", path.Base(client.systemPath),
+		"{chassis}", path.Base(client.chassisPath),
+	)
+
+	// A failing metric must not prevent the remaining ones, but the error
+	// is still returned so that it is counted
+	var lastErr error
+
+	for _, m := range mc.Metrics {
+		var doc interface{}
+
+		err := client.redfishGet(replacer.Replace(m.Config.Path), &doc)
+		if err != nil {
+			logging.Errorf(err, "Error reading custom metric %s for target '%s'", m.Config.Name, client.hostname)
+			lastErr = err
+			continue
+		}
+
+		items := []interface{}{doc}
+		if m.Config.Each != "" {
+			list, ok := jsonPointer(doc, m.Config.Each)
+			if !ok {
+				continue
+			}
+			items, ok = list.([]interface{})
+			if !ok {
+				continue
+			}
+		}
+
+		// Skip items that would result in duplicate series
+		seen := map[string]bool{}
+
+		for _, item := range items {
+			value := 1.0
+			if m.Config.Value != "" {
+				v, ok := jsonPointer(item, m.Config.Value)
+				if !ok {
+					continue
+				}
+				value, ok = customValue(v)
+				if !ok {
+					continue
+				}
+			}
+
+			labels := make([]string, len(m.LabelKeys))
+			for i, k := range m.LabelKeys {
+				v, ok := jsonPointer(item, m.Config.Labels[k])
+				if ok && v != nil {
+					labels[i] = fmt.Sprint(v)
+				}
+			}
+
+			key := strings.Join(labels, "\x00")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			ch <- mc.NewCustomMetric(m, value, labels)
+		}
+	}
+
+	return lastErr
+}
+
+// customValue converts a JSON value into a metric value
+func customValue(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case bool:
+		if x {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(x, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func (client *Client) RefreshOem(mc *metrics.OemMetricGroup, ch chan<- prometheus.Metric) error {
+	ch <- mc.NewOemVendorInfo(client.vendor)
+
+	if client.oem == nil {
+		return nil
+	}
+
+	return client.oem.refresh(client, mc, ch)
+}
+
+func (client *Client) RefreshPower(mc *metrics.PowerMetricGroup, ch chan<- prometheus.Metric) error {
+	var resp PowerResponse
+
+	err := client.redfishGet(client.powerPath, &resp)
+	if err != nil {
+		return err
+	}
+
+	for i, psu := range resp.PowerSupplies {
+		if psu.Status.State != StateEnabled {
+			continue
+		}
+
+		id := strconv.Itoa(i)
+		ch <- mc.NewPowerSupplyInputWatts(psu.PowerInputWatts, id)
+		ch <- mc.NewPowerSupplyInputVoltage(psu.LineInputVoltage, id)
+		ch <- mc.NewPowerSupplyOutputWatts(psu.GetOutputPower(), id)
+		ch <- mc.NewPowerSupplyCapacityWatts(psu.PowerCapacityWatts, id)
+		ch <- mc.NewPowerSupplyEfficiencyPercent(psu.EfficiencyPercent, id)
+	}
+
+	for i, pc := range resp.PowerControl {
+		id := strconv.Itoa(i)
+		ch <- mc.NewPowerControlConsumedWatts(pc.PowerConsumedWatts, id, pc.Name)
+		ch <- mc.NewPowerControlCapacityWatts(pc.PowerCapacityWatts, id, pc.Name)
+
+		if pc.PowerMetrics == nil {
+			continue
+		}
+
+		pm := pc.PowerMetrics
+		ch <- mc.NewPowerControlMinConsumedWatts(pm.MinConsumedWatts, id, pc.Name)
+		ch <- mc.NewPowerControlMaxConsumedWatts(pm.MaxConsumedWatts, id, pc.Name)
+		ch <- mc.NewPowerControlAvgConsumedWatts(pm.AverageConsumedWatts, id, pc.Name)
+		ch <- mc.NewPowerControlInterval(pm.IntervalInMinutes, id, pc.Name)
+	}
+
+	if len(resp.PowerControl) > 0 {
+		joules, err := client.energyJoules(resp.PowerControl[0].PowerConsumedWatts)
+		if err != nil {
+			return err
+		}
+		ch <- mc.NewPowerControlEnergy(joules)
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshIdracSel(mc *metrics.IdracSelMetricGroup, ch chan<- prometheus.Metric) error {
+	var resp IdracSelResponse
+
+	err := client.redfishGet(redfishRootPath+"/Managers/iDRAC.Embedded.1/Logs/Sel", &resp)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range resp.Members {
+		st := string(e.SensorType)
+		if st == "" {
+			st = "Unknown"
+		}
+		ch <- mc.NewSelEntry(e.Id, e.Message, st, e.Severity, e.Created)
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshLclog(mc *metrics.LclogMetricGroup, ch chan<- prometheus.Metric) error {
+	client.lclogMu.Lock()
+	defer client.lclogMu.Unlock()
+
+	var entries []LogEntry
+	cursor := client.lclogCursor
+	path := redfishRootPath + "/Managers/iDRAC.Embedded.1/LogServices/Lclog/Entries"
+
+	// Timestamps only have a resolution of one second, so the entries at the
+	// cursor are told apart by their id
+	seen := map[string]bool{}
+	for _, e := range client.lclogEntries {
+		if e.Created.Equal(cursor) {
+			seen[e.Id] = true
+		}
+	}
+
+	// Entries are returned newest first, so paging stops at the first entry
+	// older than the cursor. Without a cursor only the first page is read.
+	for path != "" {
+		var resp LclogResponse
+
+		err := client.redfishGet(path, &resp)
+		if err != nil {
+			return err
+		}
+
+		done := cursor.IsZero()
+		for _, e := range resp.Members {
+			if e.Created.Before(cursor) {
+				done = true
+				break
+			}
+			if seen[e.Id] {
+				continue
+			}
+			entries = append(entries, e)
+		}
+
+		if done || len(entries) >= lclogMaxEntries {
+			break
+		}
+
+		path = resp.NextLink
+	}
+
+	entries = append(entries, client.lclogEntries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Created.After(entries[j].Created)
+	})
+	if len(entries) > lclogMaxEntries {
+		entries = entries[:lclogMaxEntries]
+	}
+
+	client.lclogEntries = entries
+	if len(entries) > 0 {
+		client.lclogCursor = entries[0].Created
+	}
+
+	for _, e := range entries {
+		category := e.GetCategory()
+		if category == "" {
+			category = "Unknown"
+		}
+		ch <- mc.NewLclogEntry(e.Id, e.Message, e.MessageId, category, e.Severity, e.Created)
+	}
+
+	return nil
+}
+
+// storageId qualifies an id that is only unique within a storage resource with
+// the id of that resource. Dell ids already contain it, either as the whole id
+// (controllers) or after a colon (volumes), and are kept as they are.
+func storageId(parent, id string) string {
+	if id == parent || strings.HasSuffix(id, ":"+parent) {
+		return id
+	}
+	return parent + "." + id
+}
+
+func (client *Client) RefreshStorage(mc *metrics.StorageMetricGroup, ch chan<- prometheus.Metric) error {
+	var group GroupResponse
+	var err error
+	var drives int
+
+	if client.storagePath != "" {
+		err = client.redfishGet(client.storagePath, &group)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range group.Members {
+		var controller StorageController
+
+		err = client.redfishGet(c.OdataId, &controller)
+		if err != nil {
+			return err
+		}
+
+		for i, sc := range controller.StorageControllers {
+			if sc.Status.State == StateAbsent {
+				continue
+			}
+
+			id := sc.MemberId
+			if id == "" {
+				id = strconv.Itoa(i)
+			}
+			id = storageId(controller.Id, id)
+
+			ch <- mc.NewControllerInfo(id, sc.Name, sc.Manufacturer, sc.Model, sc.FirmwareVersion)
+			ch <- mc.NewControllerHealth(id, sc.Status.Health)
+
+			if sc.SpeedGbps != nil {
+				ch <- mc.NewControllerSpeed(id, *sc.SpeedGbps)
+			}
+
+			if sc.Links == nil {
+				continue
+			}
+
+			for _, b := range sc.Links.Batteries {
+				var battery Battery
+
+				err = client.redfishGet(b.OdataId, &battery)
+				if err != nil {
+					return err
+				}
+
+				if battery.Status.State == StateAbsent {
+					continue
+				}
+
+				ch <- mc.NewControllerBatteryHealth(id, battery.Name, battery.Status.Health)
+			}
+		}
+
+		if controller.Oem != nil && controller.Oem.Dell != nil && controller.Oem.Dell.DellControllerBattery != nil {
+			b := controller.Oem.Dell.DellControllerBattery
+			ch <- mc.NewControllerBatteryHealth(controller.Id, b.Name, dellHealth(b.PrimaryStatus))
+		}
+
+		if controller.Volumes.OdataId != "" {
+			var volumes GroupResponse
+
+			err = client.redfishGet(controller.Volumes.OdataId, &volumes)
+			if err != nil {
+				return err
+			}
+
+			for _, volume := range volumes.Members {
+				var v Volume
+
+				err = client.redfishGet(volume.OdataId, &v)
+				if err != nil {
+					return err
+				}
+
+				id := storageId(controller.Id, v.Id)
+				ch <- mc.NewVolumeInfo(id, v.Name, v.RAIDType, v.VolumeType, v.Encrypted)
+				ch <- mc.NewVolumeHealth(id, v.Status.Health)
+				ch <- mc.NewVolumeState(id, v.GetState())
+				ch <- mc.NewVolumeCapacity(id, v.CapacityBytes)
+				ch <- mc.NewVolumeStripeSize(id, v.GetStripSize())
+
+				for _, op := range v.Operations {
+					ch <- mc.NewVolumeOperationPercent(id, op.OperationName, op.PercentageComplete)
+				}
+			}
+		}
+
+		for _, drive := range controller.Drives {
+			var d Drive
+
+			err = client.redfishGet(drive.OdataId, &d)
+			if err != nil {
+				return err
+			}
+
+			drives++
+			ch <- mc.NewDriveInfo(d.Id, d.Name, d.Manufacturer, d.Model, d.SerialNumber, d.MediaType, d.Protocol, d.GetSlot())
+			ch <- mc.NewDriveHealth(d.Id, d.Status.Health)
+			ch <- mc.NewDriveCapacity(d.Id, d.CapacityBytes)
+			ch <- mc.NewDriveFailurePredicted(d.Id, d.FailurePredicted)
+			ch <- mc.NewDriveHotspare(d.Id, d.HotspareType)
+
+			if d.Revision != "" {
+				ch <- mc.NewDriveFirmwareInfo(d.Id, d.Revision)
+			}
+
+			if d.BlockSizeBytes != nil {
+				ch <- mc.NewDriveBlockSize(d.Id, *d.BlockSizeBytes)
+			}
+
+			// Missing link speeds are not reported as 0, which would look
+			// like a degraded link
+			if d.CapableSpeedGbs != nil {
+				ch <- mc.NewDriveCapableSpeed(d.Id, *d.CapableSpeedGbs)
+			}
+
+			if d.NegotiatedSpeedGbs != nil {
+				ch <- mc.NewDriveNegotiatedSpeed(d.Id, *d.NegotiatedSpeedGbs)
+			}
+
+			if d.RotationSpeedRPM > 0 {
+				ch <- mc.NewDriveRotationSpeed(d.Id, d.RotationSpeedRPM)
+			}
+
+			if d.PredictedMediaLifeLeftPercent != nil {
+				ch <- mc.NewDriveLifeLeft(d.Id, *d.PredictedMediaLifeLeftPercent)
+			}
+		}
+	}
+
+	// Some systems (such as iLO 5) only report the drives of array
+	// controllers through OEM resources, even when other storage is present
+	if drives == 0 {
+		if p, ok := client.oem.(oemStorageRefresher); ok {
+			return p.refreshStorage(client, mc, ch)
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshMemory(mc *metrics.MemoryMetricGroup, ch chan<- prometheus.Metric) error {
+	var group GroupResponse
+
+	err := client.redfishGet(client.memoryPath, &group)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range group.Members {
+		var m Memory
+
+		err = client.redfishGet(c.OdataId, &m)
+		if err != nil {
+			return err
+		}
+
+		if m.Status.State == StateAbsent {
+			continue
+		}
+
+		ch <- mc.NewMemoryModuleInfo(m.Id, m.Name, m.Manufacturer, m.MemoryDeviceType, m.SerialNumber, m.ErrorCorrection, m.RankCount)
+		ch <- mc.NewMemoryModuleHealth(m.Id, m.Status.Health)
+		ch <- mc.NewMemoryModuleCapacity(m.Id, m.CapacityMiB * 1048576)
+		ch <- mc.NewMemoryModuleSpeed(m.Id, m.OperatingSpeedMhz)
+
+		if m.Metrics.OdataId != "" {
+			var mm MemoryMetrics
+
+			err = client.redfishGet(m.Metrics.OdataId, &mm)
+			if err != nil {
+				return err
+			}
+
+			if mm.LifeTime != nil {
+				if mm.LifeTime.CorrectableECCErrorCount != nil {
+					ch <- mc.NewMemoryModuleCorrectableErrors(m.Id, *mm.LifeTime.CorrectableECCErrorCount)
+				}
+				if mm.LifeTime.UncorrectableECCErrorCount != nil {
+					ch <- mc.NewMemoryModuleUncorrectableErrors(m.Id, *mm.LifeTime.UncorrectableECCErrorCount)
+				}
+			}
+
+			if mm.HealthData != nil {
+				for alarm, tripped := range mm.HealthData.AlarmTrips {
+					ch <- mc.NewMemoryModuleAlarmTrip(m.Id, alarm, tripped)
+				}
+			}
+		}
+
+		if m.EnvironmentMetrics.OdataId != "" {
+			var em EnvironmentMetrics
+
+			err = client.redfishGet(m.EnvironmentMetrics.OdataId, &em)
+			if err != nil {
+				return err
+			}
+
+			if em.TemperatureCelsius != nil && em.TemperatureCelsius.Reading != nil {
+				ch <- mc.NewMemoryModuleTemperature(m.Id, *em.TemperatureCelsius.Reading)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshAccelerators(mc *metrics.AcceleratorsMetricGroup, ch chan<- prometheus.Metric) error {
+	var group GroupResponse
+	var found int
+
+	err := client.redfishGet(client.processorsPath, &group)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range group.Members {
+		var p Processor
+
+		err = client.redfishGet(c.OdataId, &p)
+		if err != nil {
+			return err
+		}
+
+		if p.Status.State == StateAbsent || !p.IsAccelerator() {
+			continue
+		}
+
+		found++
+		ch <- mc.NewAcceleratorInfo(p.Id, p.Name, p.ProcessorType, p.Manufacturer, p.Model, p.SerialNumber, p.FirmwareVersion)
+		ch <- mc.NewAcceleratorHealth(p.Id, p.Status.Health)
+
+		var temperature, power *float64
+
+		if p.Metrics.OdataId != "" {
+			var pm ProcessorMetrics
+
+			err = client.redfishGet(p.Metrics.OdataId, &pm)
+			if err != nil {
+				return err
+			}
+
+			temperature = pm.TemperatureCelsius
+			power = pm.ConsumedPowerWatt
+		}
+
+		// Newer implementations report these through EnvironmentMetrics instead
+		if p.EnvironmentMetrics.OdataId != "" {
+			var em EnvironmentMetrics
+
+			err = client.redfishGet(p.EnvironmentMetrics.OdataId, &em)
+			if err != nil {
+				return err
+			}
+
+			if em.TemperatureCelsius != nil && em.TemperatureCelsius.Reading != nil {
+				temperature = em.TemperatureCelsius.Reading
+			}
+			if em.PowerWatts != nil && em.PowerWatts.Reading != nil {
+				power = em.PowerWatts.Reading
+			}
+		}
+
+		if temperature != nil {
+			ch <- mc.NewAcceleratorTemperature(p.Id, *temperature)
+		}
+		if power != nil {
+			ch <- mc.NewAcceleratorPower(p.Id, *power)
+		}
+	}
+
+
+	// Systems that do not report accelerators as processors may still list
+	// them as PCIe devices, although without temperature and power readings
+	if found == 0 {
+		return client.refreshPCIeAccelerators(mc, ch)
+	}
+	return nil
+}
+
+func (client *Client) refreshPCIeAccelerators(mc *metrics.AcceleratorsMetricGroup, ch chan<- prometheus.Metric) error {
+	var system SystemResponse
+	var chassis ChassisResponse
+	var group GroupResponse
+
+	err := client.redfishGet(client.systemPath, &system)
+	if err != nil {
+		return err
+	}
+
+	// Newer implementations list the devices in a collection under the chassis
+	devices := system.PCIeDevices
+	if len(devices) == 0 {
+		err = client.redfishGet(client.chassisPath, &chassis)
+		if err != nil {
+			return err
+		}
+
+		if chassis.PCIeDevices.OdataId != "" {
+			err = client.redfishGet(chassis.PCIeDevices.OdataId, &group)
+			if err != nil {
+				return err
+			}
+			devices = group.Members
+		}
+	}
+
+	for _, c := range devices {
+		var d PCIeDevice
+
+		err = client.redfishGet(c.OdataId, &d)
+		if err != nil {
+			return err
+		}
+
+		if d.Status.State == StateAbsent {
+			continue
+		}
+
+		functions := d.Links.PCIeFunctions
+		if d.PCIeFunctions.OdataId != "" {
+			var group GroupResponse
+
+			err = client.redfishGet(d.PCIeFunctions.OdataId, &group)
+			if err != nil {
+				return err
+			}
+
+			functions = group.Members
+		}
+
+		acceleratorType := ""
+		for _, f := range functions {
+			var function PCIeFunction
+
+			err = client.redfishGet(f.OdataId, &function)
+			if err != nil {
+				return err
+			}
+
+			acceleratorType = function.GetAcceleratorType()
+			if acceleratorType != "" {
+				break
+			}
+		}
+
+		if acceleratorType == "" {
+			continue
+		}
+
+		ch <- mc.NewAcceleratorInfo(d.Id, d.Name, acceleratorType, d.Manufacturer, d.Model, d.SerialNumber, d.FirmwareVersion)
+		ch <- mc.NewAcceleratorHealth(d.Id, d.Status.Health)
+	}
+
+	return nil
+}
+
+func (client *Client) RefreshInventory(mc *metrics.InventoryMetricGroup, ch chan<- prometheus.Metric) error {
+	var group GroupResponse
+	var power PowerResponse
+
+	now := time.Now()
+
+	// Drives
+	drives := map[string]string{}
+
+	if client.storagePath != "" {
+		err := client.redfishGet(client.storagePath, &group)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range group.Members {
+			var controller StorageController
+
+			err = client.redfishGet(c.OdataId, &controller)
+			if err != nil {
+				return err
+			}
+
+			for _, drive := range controller.Drives {
+				var d Drive
+
+				err = client.redfishGet(drive.OdataId, &d)
+				if err != nil {
+					return err
+				}
+
+				if d.Status.State == StateAbsent {
+					continue
+				}
+
+				drives[d.Id] = d.SerialNumber
+			}
+		}
+	}
+
+	// Same fallback to the OEM resources as for the storage metrics
+	if len(drives) == 0 {
+		if p, ok := client.oem.(oemStorageRefresher); ok {
+			oemDrives, err := p.driveSerials(client)
+			if err != nil {
+				return err
+			}
+			drives = oemDrives
+		}
+	}
+
+	client.inventory.update(client.hostname, InventoryDrive, drives, now)
+
+	// Memory modules
+	modules := map[string]string{}
+
+	err := client.redfishGet(client.memoryPath, &group)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range group.Members {
+		var m Memory
+
+		err = client.redfishGet(c.OdataId, &m)
+		if err != nil {
+			return err
+		}
+
+		if m.Status.State == StateAbsent {
+			continue
+		}
+
+		modules[m.Id] = m.SerialNumber
+	}
+
+	client.inventory.update(client.hostname, InventoryMemory, modules, now)
+
+	// Power supplies
+	psus := map[string]string{}
+
+	err = client.redfishGet(client.powerPath, &power)
+	if err != nil {
+		return err
+	}
+
+	for i, psu := range power.PowerSupplies {
+		if psu.Status.State == StateAbsent {
+			continue
+		}
+
+		psus[strconv.Itoa(i)] = psu.SerialNumber
+	}
+
+	client.inventory.update(client.hostname, InventoryPSU, psus, now)
+
+	client.inventory.mu.Lock()
+	defer client.inventory.mu.Unlock()
+
+	for componentType, count := range client.inventory.changes {
+		ch <- mc.NewInventoryChanges(componentType, count)
+	}
+
+	if !client.inventory.lastChange.IsZero() {
+		ch <- mc.NewInventoryLastChange(client.inventory.lastChange.Unix())
+	}
+
+	return nil
+}
+
+func (client *Client) redfishGet(path string, res interface{}) error {
+	
+	client.requestMu.Lock()
+	defer client.requestMu.Unlock()
+
+	url := "https://" + client.hostname + path
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Authorization", "Basic " + client.basicAuth)
+	req.Header.Add("Accept", "application/json")
+
+	logging.Debugf("Querying url %q", url)
+
+	resp, err := client.httpClient.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		logging.Errorf(err, "Failed to query url %q: %v")
+		return err
+	}
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		client.peerCertificate = resp.TLS.PeerCertificates[0]
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		logging.Debugf("Query to url %q returned not found", url)
+
+		// A discovered endpoint that disappeared triggers a rediscovery,
+		// unless it was still advertised after the last rediscovery
+		if client.isEndpoint(path) && !client.missing[path] {
+			if client.missing == nil {
+				client.missing = map[string]bool{}
+			}
+			client.missing[path] = true
+			client.stale = true
+		}
+
+		return fmt.Errorf("%w: %s", errNotFound, path)
+	}
+
+	if resp.StatusCode != 200 {
+		logging.Debugf("Query to url %q returned unexpected status code: %d (%s)", url, resp.StatusCode, resp.Status)
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(res)
+	if err != nil {
+		logging.Debugf("Error decoding response from url %q: %v", url, err)
+		return err
+	}
+
+	return nil
+}
+
+func (client *Client) redfishPost(path string, body interface{}) (string, error) {
+	resp, err := client.redfishSend("POST", path, body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	return resp.Header.Get("Location"), nil
+}
+
+func (client *Client) redfishDelete(path string) error {
+	resp, err := client.redfishSend("DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func (client *Client) redfishSend(method, path string, body interface{}) (*http.Response, error) {
+	var data []byte
+	var err error
+
+	client.requestMu.Lock()
+	defer client.requestMu.Unlock()
+
+	url := "https://" + client.hostname + path
+
+	if body != nil {
+		data, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Authorization", "Basic " + client.basicAuth)
+	req.Header.Add("Accept", "application/json")
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	logging.Debugf("Sending %s request to url %q", method, url)
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		logging.Errorf(err, "Failed to send %s request to url %q", method, url)
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		logging.Debugf("%s request to url %q returned unexpected status code: %d (%s)", method, url, resp.StatusCode, resp.Status)
+		return nil, fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+
+	return resp, nil
 }
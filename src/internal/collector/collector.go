@@ -4,6 +4,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"errors"
 
 	"github.com/mrlhansen/idrac_exporter/internal/config"
@@ -45,13 +46,27 @@ type Collector struct {
 	IdracSelMetricGroup       MetricGroupRefresher[*metrics.IdracSelMetricGroup]
 	StorageMetricGroup    	  MetricGroupRefresher[*metrics.StorageMetricGroup]
 	MemoryMetricGroup     	  MetricGroupRefresher[*metrics.MemoryMetricGroup]
+	LclogMetricGroup          MetricGroupRefresher[*metrics.LclogMetricGroup]
+	ChassisMetricGroup        MetricGroupRefresher[*metrics.ChassisMetricGroup]
+	SecurityMetricGroup       MetricGroupRefresher[*metrics.SecurityMetricGroup]
+	BootMetricGroup           MetricGroupRefresher[*metrics.BootMetricGroup]
+	BiosMetricGroup           MetricGroupRefresher[*metrics.BiosMetricGroup]
+	CertificatesMetricGroup   MetricGroupRefresher[*metrics.CertificatesMetricGroup]
+	AccountsMetricGroup       MetricGroupRefresher[*metrics.AccountsMetricGroup]
+	TelemetryMetricGroup      MetricGroupRefresher[*metrics.TelemetryMetricGroup]
+	EventsMetricGroup         MetricGroupRefresher[*metrics.EventsMetricGroup]
+	CustomMetricGroup         MetricGroupRefresher[*metrics.CustomMetricGroup]
+	OemMetricGroup            MetricGroupRefresher[*metrics.OemMetricGroup]
+	AcceleratorsMetricGroup   MetricGroupRefresher[*metrics.AcceleratorsMetricGroup]
+	InventoryMetricGroup      MetricGroupRefresher[*metrics.InventoryMetricGroup]
 
 	// Exporter
 	ExporterBuildInfo         *prometheus.Desc
 	ExporterScrapeErrorsTotal *prometheus.Desc
+	ExporterRediscoveries     *prometheus.Desc
 }
 
-func NewCollector(metricGroupType metrics.MetricGroupType) *Collector {
+func NewCollector(metricGroupType metrics.MetricGroupType) (*Collector, error) {
 	prefix := config.Config.MetricsPrefix
 
 	collector := &Collector{
@@ -69,6 +84,11 @@ func NewCollector(metricGroupType metrics.MetricGroupType) *Collector {
 			"Total number of errors encountered while scraping target",
 			nil, nil,
 		),
+		ExporterRediscoveries: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "exporter", "rediscoveries_total"),
+			"Total number of times the endpoints of the target were rediscovered",
+			nil, nil,
+		),
 	}
 	
 	collector.SystemMetricGroup = MetricGroupRefresher[*metrics.SystemMetricGroup] {
@@ -113,19 +133,115 @@ func NewCollector(metricGroupType metrics.MetricGroupType) *Collector {
 		},
 	}
 
+	collector.LclogMetricGroup = MetricGroupRefresher[*metrics.LclogMetricGroup] {
+		metricGroup: metrics.NewLclogMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.LclogMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshLclog(metricGroup, ch)
+		},
+	}
+
+	collector.ChassisMetricGroup = MetricGroupRefresher[*metrics.ChassisMetricGroup] {
+		metricGroup: metrics.NewChassisMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.ChassisMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshChassis(metricGroup, ch)
+		},
+	}
+
+	collector.SecurityMetricGroup = MetricGroupRefresher[*metrics.SecurityMetricGroup] {
+		metricGroup: metrics.NewSecurityMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.SecurityMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshSecurity(metricGroup, ch)
+		},
+	}
+
+	collector.BootMetricGroup = MetricGroupRefresher[*metrics.BootMetricGroup] {
+		metricGroup: metrics.NewBootMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.BootMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshBoot(metricGroup, ch)
+		},
+	}
+
+	collector.BiosMetricGroup = MetricGroupRefresher[*metrics.BiosMetricGroup] {
+		metricGroup: metrics.NewBiosMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.BiosMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshBios(metricGroup, ch)
+		},
+	}
+
+	collector.CertificatesMetricGroup = MetricGroupRefresher[*metrics.CertificatesMetricGroup] {
+		metricGroup: metrics.NewCertificatesMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.CertificatesMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshCertificates(metricGroup, ch)
+		},
+	}
+
+	collector.AccountsMetricGroup = MetricGroupRefresher[*metrics.AccountsMetricGroup] {
+		metricGroup: metrics.NewAccountsMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.AccountsMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshAccounts(metricGroup, ch)
+		},
+	}
+
+	collector.TelemetryMetricGroup = MetricGroupRefresher[*metrics.TelemetryMetricGroup] {
+		metricGroup: metrics.NewTelemetryMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.TelemetryMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshTelemetry(metricGroup, ch)
+		},
+	}
+
+	collector.EventsMetricGroup = MetricGroupRefresher[*metrics.EventsMetricGroup] {
+		metricGroup: metrics.NewEventsMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.EventsMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshEvents(metricGroup, ch)
+		},
+	}
+
+	collector.CustomMetricGroup = MetricGroupRefresher[*metrics.CustomMetricGroup] {
+		metricGroup: metrics.NewCustomMetricGroup(prefix, config.Config.CustomMetrics),
+		refresh: func(client *Client, metricGroup *metrics.CustomMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshCustom(metricGroup, ch)
+		},
+	}
+
+	collector.OemMetricGroup = MetricGroupRefresher[*metrics.OemMetricGroup] {
+		metricGroup: metrics.NewOemMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.OemMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshOem(metricGroup, ch)
+		},
+	}
+
+	collector.AcceleratorsMetricGroup = MetricGroupRefresher[*metrics.AcceleratorsMetricGroup] {
+		metricGroup: metrics.NewAcceleratorsMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.AcceleratorsMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshAccelerators(metricGroup, ch)
+		},
+	}
+
+	collector.InventoryMetricGroup = MetricGroupRefresher[*metrics.InventoryMetricGroup] {
+		metricGroup: metrics.NewInventoryMetricGroup(prefix),
+		refresh: func(client *Client, metricGroup *metrics.InventoryMetricGroup, ch chan<- prometheus.Metric) error {
+			return client.RefreshInventory(metricGroup, ch)
+		},
+	}
+
 	collector.builder = new(strings.Builder)
 	collector.collected = sync.NewCond(new(sync.Mutex))
 	collector.registry = prometheus.NewRegistry()
-	collector.registry.Register(collector)
-
 	collector.selectedMetricGroupType = metricGroupType
 
-	return collector
+	// Fails when a custom metric collides with one of the built-in metrics
+	err := collector.registry.Register(collector)
+	if err != nil {
+		return nil, err
+	}
+
+	return collector, nil
 }
 
 func (collector *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- collector.ExporterBuildInfo
 	ch <- collector.ExporterScrapeErrorsTotal
+	ch <- collector.ExporterRediscoveries
 	
 	collector.SystemMetricGroup.metricGroup.Describe(ch)
 	collector.SensorsMetricGroup.metricGroup.Describe(ch)
@@ -133,6 +249,19 @@ func (collector *Collector) Describe(ch chan<- *prometheus.Desc) {
 	collector.IdracSelMetricGroup.metricGroup.Describe(ch)
 	collector.StorageMetricGroup.metricGroup.Describe(ch)
 	collector.MemoryMetricGroup.metricGroup.Describe(ch)
+	collector.LclogMetricGroup.metricGroup.Describe(ch)
+	collector.ChassisMetricGroup.metricGroup.Describe(ch)
+	collector.SecurityMetricGroup.metricGroup.Describe(ch)
+	collector.BootMetricGroup.metricGroup.Describe(ch)
+	collector.BiosMetricGroup.metricGroup.Describe(ch)
+	collector.CertificatesMetricGroup.metricGroup.Describe(ch)
+	collector.AccountsMetricGroup.metricGroup.Describe(ch)
+	collector.TelemetryMetricGroup.metricGroup.Describe(ch)
+	collector.EventsMetricGroup.metricGroup.Describe(ch)
+	collector.CustomMetricGroup.metricGroup.Describe(ch)
+	collector.OemMetricGroup.metricGroup.Describe(ch)
+	collector.AcceleratorsMetricGroup.metricGroup.Describe(ch)
+	collector.InventoryMetricGroup.metricGroup.Describe(ch)
 }
 
 func tryRefresh[T metrics.MetricGroup](collector *Collector, metricGroup MetricGroupRefresher[T], ch chan<- prometheus.Metric) error {
@@ -144,6 +273,10 @@ func tryRefresh[T metrics.MetricGroup](collector *Collector, metricGroup MetricG
 		return nil
 	}
 
+	// Rediscovery swaps the endpoints of the client while holding the lock
+	collector.client.endpointsMu.RLock()
+	defer collector.client.endpointsMu.RUnlock()
+
 	return metricGroup.refresh(collector.client, metricGroup.metricGroup, ch)
 }
 
@@ -173,8 +306,61 @@ func (collector *Collector) Collect(ch chan<- prometheus.Metric) {
         collector.errors++
     }
 
+	if err := tryRefresh(collector, collector.LclogMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.ChassisMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.SecurityMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.BootMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.BiosMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.CertificatesMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.AccountsMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.TelemetryMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.EventsMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.CustomMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.OemMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.AcceleratorsMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
+	if err := tryRefresh(collector, collector.InventoryMetricGroup, ch); err != nil {
+        collector.errors++
+    }
+
 	ch <- prometheus.MustNewConstMetric(collector.ExporterBuildInfo, prometheus.UntypedValue, 1)
 	ch <- prometheus.MustNewConstMetric(collector.ExporterScrapeErrorsTotal, prometheus.GaugeValue, float64(collector.errors))
+	ch <- prometheus.MustNewConstMetric(collector.ExporterRediscoveries, prometheus.CounterValue, float64(atomic.LoadUint64(&collector.client.rediscoveries)))
 }
 
 func (collector *Collector) Gather() (string, error) {
@@ -233,7 +419,12 @@ func GetCollector(target string, metricGroupType metrics.MetricGroupType) (*Coll
 	mu.Lock()
 	collector, ok := collectors[key]
 	if !ok {
-		collector = NewCollector(metricGroupType)
+		var err error
+		collector, err = NewCollector(metricGroupType)
+		if err != nil {
+			mu.Unlock()
+			return nil, err
+		}
 		collectors[key] = collector
 	}
 	mu.Unlock()
@@ -253,5 +444,8 @@ func GetCollector(target string, metricGroupType metrics.MetricGroupType) (*Coll
 		collector.client = client
 	}
 
+	// Endpoints may have moved after a firmware upgrade
+	collector.client.revalidate(target)
+
 	return collector, nil
 }
@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/mrlhansen/idrac_exporter/internal/logging"
+)
+
+// Gaps between power samples longer than this are not integrated when the
+// background sampler is disabled
+const energyMaxGap = 5 * time.Minute
+
+// energyMeter accumulates the energy consumed by a target by integrating the
+// power readings with the trapezoidal rule. It is only used when the BMC does
+// not report the energy itself.
+type energyMeter struct {
+	mu       sync.Mutex
+	joules   float64
+	watts    float64
+	last     time.Time
+	reported bool
+	sampling bool
+}
+
+func (m *energyMeter) sample(watts float64, now time.Time) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Samples from scrapes and the background sampler may arrive out of order
+	if !m.last.IsZero() {
+		if !now.After(m.last) {
+			return m.joules
+		}
+
+		// Long gaps, for example while the BMC was unreachable, are skipped
+		// rather than filled with energy that was never measured
+		if gap := now.Sub(m.last); gap <= energyGapLimit() {
+			m.joules += (m.watts + watts) / 2 * gap.Seconds()
+		}
+	}
+
+	m.watts = watts
+	m.last = now
+
+	return m.joules
+}
+
+// setReported switches between the energy reported by the BMC and the
+// integrated energy. The integration starts over after a switch, so the time
+// spent in the other mode is not integrated.
+func (m *energyMeter) setReported(reported bool) {
+	m.mu.Lock()
+	if m.reported != reported {
+		m.last = time.Time{}
+	}
+	m.reported = reported
+	m.mu.Unlock()
+}
+
+// energyGapLimit returns the longest gap between two samples that is integrated
+func energyGapLimit() time.Duration {
+	if config.Config.PowerSampleInterval > 0 {
+		return 3 * time.Duration(config.Config.PowerSampleInterval) * time.Second
+	}
+	return energyMaxGap
+}
+
+func (m *energyMeter) isReported() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.reported
+}
+
+// energyJoules returns the total energy consumed by the target. The energy
+// reported in the chassis environment metrics is preferred, otherwise the
+// given power reading is integrated.
+func (client *Client) energyJoules(watts float64) (float64, error) {
+	if client.environmentPath != "" {
+		var em EnvironmentMetrics
+
+		err := client.redfishGet(client.environmentPath, &em)
+		if err != nil {
+			return 0, err
+		}
+
+		if em.EnergykWh != nil && em.EnergykWh.Reading != nil {
+			client.energy.setReported(true)
+			return *em.EnergykWh.Reading * 3.6e6, nil
+		}
+	}
+
+	client.energy.setReported(false)
+	return client.energy.sample(watts, time.Now()), nil
+}
+
+// startPowerSampler samples the power consumption in the background, so the
+// integrated energy does not depend on the scrape interval
+func (client *Client) startPowerSampler() {
+	if config.Config.PowerSampleInterval <= 0 {
+		return
+	}
+	interval := time.Duration(config.Config.PowerSampleInterval) * time.Second
+
+	client.energy.mu.Lock()
+	defer client.energy.mu.Unlock()
+
+	if client.energy.sampling {
+		return
+	}
+	client.energy.sampling = true
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-shutdownCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if client.energy.isReported() {
+				continue
+			}
+
+			var resp PowerResponse
+
+			client.endpointsMu.RLock()
+			err := client.redfishGet(client.powerPath, &resp)
+			client.endpointsMu.RUnlock()
+			if err != nil {
+				logging.Errorf(err, "Error sampling power for target '%s'", client.hostname)
+				continue
+			}
+
+			if len(resp.PowerControl) > 0 {
+				client.energy.sample(resp.PowerControl[0].PowerConsumedWatts, time.Now())
+			}
+		}
+	}()
+}
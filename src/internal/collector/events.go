@@ -0,0 +1,403 @@
+package collector
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/mrlhansen/idrac_exporter/internal/logging"
+)
+
+type eventKey struct {
+	severity  string
+	messageId string
+}
+
+type storedEvent struct {
+	Event
+	seq uint64
+}
+
+// eventStore holds event counters and the most recent events for a target
+type eventStore struct {
+	mu        sync.Mutex
+	seq       uint64
+	counts    map[eventKey]uint64
+	health    map[string]string
+	recent    []storedEvent
+	streaming bool
+	connected bool
+}
+
+var eventStoresMu sync.Mutex
+var eventStores = map[string]*eventStore{}
+
+// Cancelled on shutdown to close all event streams and power samplers
+var shutdownCtx, shutdownCancel = context.WithCancel(context.Background())
+
+const (
+	streamMinBackoff = time.Second
+	streamMaxBackoff = 5 * time.Minute
+)
+
+func getEventStore(target string) *eventStore {
+	eventStoresMu.Lock()
+	defer eventStoresMu.Unlock()
+
+	store, ok := eventStores[target]
+	if !ok {
+		store = &eventStore{
+			counts: map[eventKey]uint64{},
+			health: map[string]string{},
+		}
+		eventStores[target] = store
+	}
+
+	return store
+}
+
+func (store *eventStore) add(e Event) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.counts[eventKey{e.GetSeverity(), e.MessageId}]++
+
+	// The latest event of a resource determines its health, so an OK event
+	// clears an earlier warning
+	if e.OriginOfCondition.OdataId != "" {
+		store.health[e.OriginOfCondition.OdataId] = e.GetSeverity()
+	}
+
+	store.seq++
+	store.recent = append(store.recent, storedEvent{e, store.seq})
+	if n := len(store.recent) - int(config.Config.Events.BufferSize); n > 0 {
+		store.recent = store.recent[n:]
+	}
+}
+
+// Separates the target from the secret in the subscription context
+const eventContextSeparator = "|"
+
+var (
+	ErrUnknownTarget = errors.New("unknown target")
+	ErrInvalidSecret = errors.New("invalid event secret")
+)
+
+// eventContext returns the subscription context for a target, which carries
+// the shared secret from the configuration when one is set
+func eventContext(target string) string {
+	if config.Config.Events.Secret == "" {
+		return target
+	}
+	return target + eventContextSeparator + config.Config.Events.Secret
+}
+
+// HandleEvents records the events in a payload pushed by a Redfish event
+// service. The target is taken from the subscription context when empty.
+// Events are only accepted for targets that have a client, and they must carry
+// the shared secret when one is configured.
+func HandleEvents(target string, body io.Reader) error {
+	resp, err := decodeEvents(body)
+	if err != nil {
+		return err
+	}
+
+	contextTarget, secret, _ := strings.Cut(resp.Context, eventContextSeparator)
+	if target == "" {
+		target = contextTarget
+	}
+
+	expected := config.Config.Events.Secret
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(expected)) != 1 {
+		return ErrInvalidSecret
+	}
+
+	clientsMu.Lock()
+	_, ok := clients[target]
+	clientsMu.Unlock()
+
+	if !ok {
+		return ErrUnknownTarget
+	}
+
+	getEventStore(target).addAll(target, resp.Events)
+
+	return nil
+}
+
+func decodeEvents(body io.Reader) (*EventResponse, error) {
+	var resp EventResponse
+
+	err := json.NewDecoder(body).Decode(&resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (store *eventStore) addAll(target string, events []Event) {
+	for _, e := range events {
+		logging.Debugf("Received event %q from target '%s': %s", e.MessageId, target, e.Message)
+		store.add(e)
+	}
+}
+
+func (store *eventStore) setConnected(connected bool) {
+	store.mu.Lock()
+	store.streaming = true
+	store.connected = connected
+	store.mu.Unlock()
+}
+
+// startStream holds the server-sent event stream of the target open until
+// shutdown, reconnecting with exponential backoff
+func (client *Client) startStream() error {
+	var service EventServiceResponse
+
+	if client.eventsPath == "" || client.streaming {
+		return nil
+	}
+
+	err := client.redfishGet(client.eventsPath, &service)
+	if err != nil {
+		return err
+	}
+
+	if service.ServerSentEventUri == "" {
+		logging.Infof("Target '%s' does not support server-sent events", client.hostname)
+		return nil
+	}
+
+	client.streaming = true
+	store := getEventStore(client.hostname)
+	store.setConnected(false)
+
+	go func() {
+		backoff := streamMinBackoff
+
+		for {
+			start := time.Now()
+			err := client.readStream(service.ServerSentEventUri, store)
+			store.setConnected(false)
+
+			if shutdownCtx.Err() != nil {
+				return
+			}
+
+			// Reset the backoff when the stream was open for a while
+			if time.Since(start) > streamMaxBackoff {
+				backoff = streamMinBackoff
+			}
+
+			logging.Errorf(err, "Event stream for target '%s' closed, reconnecting in %s", client.hostname, backoff)
+
+			select {
+			case <-shutdownCtx.Done():
+				return
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+			if backoff > streamMaxBackoff {
+				backoff = streamMaxBackoff
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (client *Client) readStream(path string, store *eventStore) error {
+	url := "https://" + client.hostname + path
+
+	req, err := http.NewRequestWithContext(shutdownCtx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Authorization", "Basic " + client.basicAuth)
+	req.Header.Add("Accept", "text/event-stream")
+
+	logging.Debugf("Opening event stream %q", url)
+
+	// The stream is kept open indefinitely, so no timeout is used here
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("%d %s", resp.StatusCode, resp.Status)
+	}
+
+	logging.Infof("Event stream for target '%s' connected", client.hostname)
+	store.setConnected(true)
+
+	var data bytes.Buffer
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		// Data lines are accumulated until an empty line ends the event
+		if len(line) > 0 {
+			if bytes.HasPrefix(line, []byte("data:")) {
+				data.Write(bytes.TrimSpace(line[5:]))
+			}
+			continue
+		}
+
+		if data.Len() == 0 {
+			continue
+		}
+
+		// Stream events come from a connection opened by the exporter, so
+		// they are not checked against the subscription secret
+		resp, err := decodeEvents(&data)
+		if err != nil {
+			logging.Errorf(err, "Error decoding event from target '%s'", client.hostname)
+		} else {
+			store.addAll(client.hostname, resp.Events)
+		}
+		data.Reset()
+	}
+
+	err = scanner.Err()
+	if err == nil {
+		err = io.EOF
+	}
+
+	return err
+}
+
+// subscribe registers an event subscription on the target, pointing at the
+// destination from the configuration. Subscriptions left behind by an earlier
+// run of the exporter are reused or removed, since targets only allow a few.
+func (client *Client) subscribe() error {
+	var service EventServiceResponse
+	var group GroupResponse
+
+	if client.eventsPath == "" || client.subscriptionPath != "" {
+		return nil
+	}
+
+	err := client.redfishGet(client.eventsPath, &service)
+	if err != nil {
+		return err
+	}
+
+	err = client.redfishGet(service.Subscriptions.OdataId, &group)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range group.Members {
+		var s EventSubscription
+
+		err = client.redfishGet(c.OdataId, &s)
+		if err != nil {
+			return err
+		}
+
+		if s.Destination != config.Config.Events.Destination {
+			continue
+		}
+
+		// Some services do not return the context, so it is only compared when present
+		if client.subscriptionPath == "" && (s.Context == "" || s.Context == eventContext(client.hostname)) {
+			client.subscriptionPath = c.OdataId
+			logging.Infof("Reusing event subscription %s for target '%s'", client.subscriptionPath, client.hostname)
+			continue
+		}
+
+		err = client.redfishDelete(c.OdataId)
+		if err != nil {
+			return err
+		}
+
+		logging.Infof("Removed stale event subscription %s for target '%s'", c.OdataId, client.hostname)
+	}
+
+	if client.subscriptionPath != "" {
+		return nil
+	}
+
+	location, err := client.redfishPost(service.Subscriptions.OdataId, &EventSubscription{
+		Destination: config.Config.Events.Destination,
+		Context:     eventContext(client.hostname),
+		Protocol:    "Redfish",
+		EventTypes:  config.Config.Events.Types,
+	})
+	if err != nil {
+		return err
+	}
+
+	// The location header may contain an absolute URL
+	u, err := url.Parse(location)
+	if err != nil {
+		return err
+	}
+
+	client.subscriptionPath = u.Path
+	logging.Infof("Registered event subscription %s for target '%s'", client.subscriptionPath, client.hostname)
+
+	return nil
+}
+
+func (client *Client) unsubscribe() error {
+	if client.subscriptionPath == "" {
+		return nil
+	}
+
+	err := client.redfishDelete(client.subscriptionPath)
+	if err != nil {
+		return err
+	}
+
+	logging.Infof("Removed event subscription %s for target '%s'", client.subscriptionPath, client.hostname)
+	client.subscriptionPath = ""
+
+	return nil
+}
+
+// Shutdown closes all event streams, removes the event subscriptions registered
+// by the exporter and writes the state file.
+func Shutdown() {
+	shutdownCancel()
+
+	for target, client := range getClients() {
+		client.configMu.Lock()
+		err := client.unsubscribe()
+		client.configMu.Unlock()
+
+		if err != nil {
+			logging.Errorf(err, "Error removing event subscription for target '%s'", target)
+		}
+	}
+
+	if err := SaveState(); err != nil {
+		logging.Errorf(err, "Error writing state file %s", stateFilePath())
+	}
+}
@@ -0,0 +1,63 @@
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mrlhansen/idrac_exporter/internal/logging"
+)
+
+// Component types tracked in the inventory
+const (
+	InventoryDrive  = "drive"
+	InventoryMemory = "memory"
+	InventoryPSU    = "psu"
+)
+
+// inventoryState holds the last known serial number of each component of a
+// target, keyed by component type and slot, together with the number of
+// changes seen since the exporter was started
+type inventoryState struct {
+	mu         sync.Mutex
+	components map[string]map[string]string
+	changes    map[string]uint64
+	lastChange time.Time
+}
+
+// update replaces the known components of the given type. Every slot where
+// the serial number differs from the last known one counts as a change,
+// including components that were added or removed. The first inventory of a
+// type is only recorded.
+func (s *inventoryState) update(target, componentType string, current map[string]string, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.components == nil {
+		s.components = map[string]map[string]string{}
+		s.changes = map[string]uint64{}
+	}
+
+	previous, ok := s.components[componentType]
+	s.components[componentType] = current
+
+	if !ok {
+		s.changes[componentType] = 0
+		return
+	}
+
+	for slot, serial := range current {
+		if previous[slot] != serial {
+			logging.Infof("Inventory change on target '%s': %s %s serial %q replaced by %q", target, componentType, slot, previous[slot], serial)
+			s.changes[componentType]++
+			s.lastChange = now
+		}
+	}
+
+	for slot, serial := range previous {
+		if _, ok := current[slot]; !ok {
+			logging.Infof("Inventory change on target '%s': %s %s serial %q removed", target, componentType, slot, serial)
+			s.changes[componentType]++
+			s.lastChange = now
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package collector
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -48,6 +50,7 @@ type V1Response struct {
 	Fabrics            Odata  `json:"Fabrics"`
 	JobService         Odata  `json:"JobService"`
 	JsonSchemas        Odata  `json:"JsonSchemas"`
+	LicenseService     Odata  `json:"LicenseService"`
 	Managers           Odata  `json:"Managers"`
 	Registries         Odata  `json:"Registries"`
 	SessionService     Odata  `json:"SessionService"`
@@ -55,6 +58,121 @@ type V1Response struct {
 	Tasks              Odata  `json:"Tasks"`
 	TelemetryService   Odata  `json:"TelemetryService"`
 	UpdateService      Odata  `json:"UpdateService"`
+
+	Oem map[string]json.RawMessage `json:"Oem"`
+}
+
+type CertificateServiceResponse struct {
+	Name                 string `json:"Name"`
+	CertificateLocations Odata  `json:"CertificateLocations"`
+}
+
+type CertificateLocationsResponse struct {
+	Name  string `json:"Name"`
+	Links struct {
+		Certificates []Odata `json:"Certificates"`
+	} `json:"Links"`
+}
+
+type Certificate struct {
+	Id            string    `json:"Id"`
+	Name          string    `json:"Name"`
+	ValidNotAfter time.Time `json:"ValidNotAfter"`
+	Subject       struct {
+		CommonName string `json:"CommonName"`
+	} `json:"Subject"`
+	Issuer struct {
+		CommonName string `json:"CommonName"`
+	} `json:"Issuer"`
+}
+
+type AccountServiceResponse struct {
+	Name     string `json:"Name"`
+	Accounts Odata  `json:"Accounts"`
+	LDAP     *struct {
+		ServiceEnabled bool `json:"ServiceEnabled"`
+	} `json:"LDAP"`
+	ActiveDirectory *struct {
+		ServiceEnabled bool `json:"ServiceEnabled"`
+	} `json:"ActiveDirectory"`
+}
+
+type ManagerAccount struct {
+	Id                 string `json:"Id"`
+	UserName           string `json:"UserName"`
+	Enabled            bool   `json:"Enabled"`
+	Locked             bool   `json:"Locked"`
+	RoleId             string `json:"RoleId"`
+	PasswordExpiration string `json:"PasswordExpiration"`
+}
+
+type TelemetryServiceResponse struct {
+	Name                    string `json:"Name"`
+	MetricReports           Odata  `json:"MetricReports"`
+	MetricReportDefinitions Odata  `json:"MetricReportDefinitions"`
+}
+
+type MetricReport struct {
+	Id           string `json:"Id"`
+	Name         string `json:"Name"`
+	MetricValues []struct {
+		MetricId       string    `json:"MetricId"`
+		MetricValue    string    `json:"MetricValue"`
+		MetricProperty string    `json:"MetricProperty"`
+		Timestamp      time.Time `json:"Timestamp"`
+	} `json:"MetricValues"`
+}
+
+type EventServiceResponse struct {
+	Name               string `json:"Name"`
+	ServiceEnabled     bool   `json:"ServiceEnabled"`
+	ServerSentEventUri string `json:"ServerSentEventUri"`
+	Subscriptions      Odata  `json:"Subscriptions"`
+}
+
+type EventSubscription struct {
+	Destination string   `json:"Destination"`
+	Context     string   `json:"Context"`
+	Protocol    string   `json:"Protocol"`
+	EventTypes  []string `json:"EventTypes"`
+}
+
+type EventResponse struct {
+	Id      string  `json:"Id"`
+	Name    string  `json:"Name"`
+	Context string  `json:"Context"`
+	Events  []Event `json:"Events"`
+}
+
+type Event struct {
+	EventId           string `json:"EventId"`
+	EventType         string `json:"EventType"`
+	EventTimestamp    string `json:"EventTimestamp"`
+	Message           string `json:"Message"`
+	MessageId         string `json:"MessageId"`
+	MessageSeverity   string `json:"MessageSeverity"`
+	Severity          string `json:"Severity"`
+	OriginOfCondition Odata  `json:"OriginOfCondition"`
+}
+
+func (e *Event) GetSeverity() string {
+	if e.MessageSeverity != "" {
+		return e.MessageSeverity
+	}
+	if e.Severity != "" {
+		return e.Severity
+	}
+	return "Unknown"
+}
+
+// GetTimestamp returns the time of the event, or the current time when the
+// event has no valid timestamp
+func (e *Event) GetTimestamp() time.Time {
+	t, err := time.Parse(time.RFC3339, e.EventTimestamp)
+	if err != nil {
+		return time.Now()
+	}
+	return t
 }
 
 type GroupResponse struct {
@@ -77,6 +195,7 @@ type ChassisResponse struct {
 	EnvironmentalClass string `json:"EnvironmentalClass"`
 	IndicatorLED       string `json:"IndicatorLED"`
 	Assembly           Odata  `json:"Assembly"`
+	EnvironmentMetrics Odata  `json:"EnvironmentMetrics"`
 	Location           *struct {
 		Info       string `json:"Info"`
 		InfoFormat string `json:"InfoFormat"`
@@ -91,6 +210,7 @@ type ChassisResponse struct {
 	} `json:"Location"`
 	Memory           Odata  `json:"Memory"`
 	NetworkAdapters  Odata  `json:"NetworkAdapters"`
+	PCIeDevices      Odata  `json:"PCIeDevices"`
 	PCIeSlots        Odata  `json:"PCIeSlots"`
 	Power            Odata  `json:"Power"`
 	Sensors          Odata  `json:"Sensors"`
@@ -178,34 +298,133 @@ type StorageController struct {
 	Name               string  `json:"Name"`
 	Description        string  `json:"Description"`
 	Drives             []Odata `json:"Drives"`
+	Volumes            Odata   `json:"Volumes"`
 	Status             Status  `json:"Status"`
 	StorageControllers []struct {
-		FirmwareVersion string  `json:"FirmwareVersion"`
-		Manufacturer    string  `json:"Manufacturer"`
-		Model           string  `json:"Model"`
-		Name            string  `json:"Name"`
-		SpeedGbps       float64 `json:"SpeedGbps"`
-		Status          Status  `json:"Status"`
+		MemberId        string   `json:"MemberId"`
+		FirmwareVersion string   `json:"FirmwareVersion"`
+		Manufacturer    string   `json:"Manufacturer"`
+		Model           string   `json:"Model"`
+		Name            string   `json:"Name"`
+		SpeedGbps       *float64 `json:"SpeedGbps"`
+		Status          Status   `json:"Status"`
+		Links           *struct {
+			Batteries []Odata `json:"Batteries"`
+		} `json:"Links"`
+	}
+	Oem *struct {
+		Dell *struct {
+			DellControllerBattery *struct {
+				Id            string `json:"Id"`
+				Name          string `json:"Name"`
+				PrimaryStatus string `json:"PrimaryStatus"`
+				RAIDState     string `json:"RAIDState"`
+			} `json:"DellControllerBattery"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+}
+
+// dellHealth converts a Dell OEM primary status into a Redfish health value
+func dellHealth(status string) string {
+	switch status {
+	case "Degraded":
+		return "Warning"
+	case "Error":
+		return "Critical"
+	}
+	return status
+}
+
+type Battery struct {
+	Id     string `json:"Id"`
+	Name   string `json:"Name"`
+	Status Status `json:"Status"`
+}
+
+type Volume struct {
+	Id                 string `json:"Id"`
+	Name               string `json:"Name"`
+	Description        string `json:"Description"`
+	RAIDType           string `json:"RAIDType"`
+	VolumeType         string `json:"VolumeType"`
+	CapacityBytes      int    `json:"CapacityBytes"`
+	Encrypted          bool   `json:"Encrypted"`
+	StripSizeBytes     int    `json:"StripSizeBytes"`
+	OptimumIOSizeBytes int    `json:"OptimumIOSizeBytes"`
+	Status             Status `json:"Status"`
+	Operations         []struct {
+		OperationName      string `json:"OperationName"`
+		PercentageComplete int    `json:"PercentageComplete"`
+	} `json:"Operations"`
+	Oem *struct {
+		Dell *struct {
+			DellVolume *struct {
+				RaidStatus string `json:"RaidStatus"`
+			} `json:"DellVolume"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+}
+
+func (v *Volume) GetStripSize() int {
+	if v.StripSizeBytes > 0 {
+		return v.StripSizeBytes
 	}
+	return v.OptimumIOSizeBytes
+}
+
+// GetState returns one of Optimal, Rebuilding, Degraded or Failed, using the
+// Dell OEM RAID status when available and the health status otherwise
+func (v *Volume) GetState() string {
+	for _, op := range v.Operations {
+		if strings.HasPrefix(op.OperationName, "Rebuild") {
+			return "Rebuilding"
+		}
+	}
+
+	if v.Oem != nil && v.Oem.Dell != nil && v.Oem.Dell.DellVolume != nil {
+		switch v.Oem.Dell.DellVolume.RaidStatus {
+		case "Online", "Ready":
+			return "Optimal"
+		case "Degraded":
+			return "Degraded"
+		case "Failed", "Offline", "Missing", "Blocked":
+			return "Failed"
+		}
+	}
+
+	switch v.Status.Health {
+	case "OK":
+		return "Optimal"
+	case "Warning":
+		return "Degraded"
+	case "Critical":
+		return "Failed"
+	}
+
+	return v.Status.Health
 }
 
 type Drive struct {
-	Id               string  `json:"Id"`
-	Name             string  `json:"Name"`
-	Description      string  `json:"Description"`
-	MediaType        string  `json:"MediaType"`
-	Manufacturer     string  `json:"Manufacturer"`
-	Model            string  `json:"Model"`
-	CapacityBytes    int     `json:"CapacityBytes"`
-	BlockSizeBytes   int     `json:"BlockSizeBytes"`
-	CapableSpeedGbs  float64 `json:"CapableSpeedGbs"`
-	Status           Status  `json:"Status"`
-	SerialNumber     string  `json:"SerialNumber"`
-	Protocol         string  `json:"Protocol"`
-	Revision         string  `json:"Revision"`
-	PartNumber       string  `json:"PartNumber"`
-	RotationSpeedRPM int     `json:"RotationSpeedRPM"`
-	PhysicalLocation *struct {
+	Id                            string   `json:"Id"`
+	Name                          string   `json:"Name"`
+	Description                   string   `json:"Description"`
+	MediaType                     string   `json:"MediaType"`
+	Manufacturer                  string   `json:"Manufacturer"`
+	Model                         string   `json:"Model"`
+	CapacityBytes                 int      `json:"CapacityBytes"`
+	BlockSizeBytes                *int     `json:"BlockSizeBytes"`
+	CapableSpeedGbs               *float64 `json:"CapableSpeedGbs"`
+	Status                        Status   `json:"Status"`
+	SerialNumber                  string   `json:"SerialNumber"`
+	Protocol                      string   `json:"Protocol"`
+	Revision                      string   `json:"Revision"`
+	PartNumber                    string   `json:"PartNumber"`
+	RotationSpeedRPM              int      `json:"RotationSpeedRPM"`
+	NegotiatedSpeedGbs            *float64 `json:"NegotiatedSpeedGbs"`
+	PredictedMediaLifeLeftPercent *float64 `json:"PredictedMediaLifeLeftPercent"`
+	FailurePredicted              bool     `json:"FailurePredicted"`
+	HotspareType                  string   `json:"HotspareType"`
+	PhysicalLocation              *struct {
 		PartLocation *struct {
 			LocationOrdinalValue int `json:"LocationOrdinalValue"`
 		} `json:"PartLocation"`
@@ -223,22 +442,105 @@ func (d *Drive) GetSlot() int {
 }
 
 type Memory struct {
-	Id                string `json:"Id"`
-	Name              string `json:"Name"`
-	Description       string `json:"Description"`
-	Manufacturer      string `json:"Manufacturer"`
-	ErrorCorrection   string `json:"ErrorCorrection"`
-	MemoryDeviceType  string `json:"MemoryDeviceType"`
-	AllowedSpeedsMHz  []int  `json:"AllowedSpeedsMHz"`
-	OperatingSpeedMhz int    `json:"OperatingSpeedMhz"`
-	CapacityMiB       int    `json:"CapacityMiB"`
-	PartNumber        string `json:"PartNumber"`
-	SerialNumber      string `json:"SerialNumber"`
-	DeviceLocator     string `json:"DeviceLocator"`
-	RankCount         int    `json:"RankCount"`
-	BusWidthBits      int    `json:"BusWidthBits"`
-	DataWidthBits     int    `json:"DataWidthBits"`
-	Status            Status `json:"Status"`
+	Id                 string `json:"Id"`
+	Name               string `json:"Name"`
+	Description        string `json:"Description"`
+	Manufacturer       string `json:"Manufacturer"`
+	ErrorCorrection    string `json:"ErrorCorrection"`
+	MemoryDeviceType   string `json:"MemoryDeviceType"`
+	AllowedSpeedsMHz   []int  `json:"AllowedSpeedsMHz"`
+	OperatingSpeedMhz  int    `json:"OperatingSpeedMhz"`
+	CapacityMiB        int    `json:"CapacityMiB"`
+	PartNumber         string `json:"PartNumber"`
+	SerialNumber       string `json:"SerialNumber"`
+	DeviceLocator      string `json:"DeviceLocator"`
+	RankCount          int    `json:"RankCount"`
+	BusWidthBits       int    `json:"BusWidthBits"`
+	DataWidthBits      int    `json:"DataWidthBits"`
+	Status             Status `json:"Status"`
+	Metrics            Odata  `json:"Metrics"`
+	EnvironmentMetrics Odata  `json:"EnvironmentMetrics"`
+}
+
+type MemoryMetrics struct {
+	HealthData *struct {
+		AlarmTrips map[string]bool `json:"AlarmTrips"`
+	} `json:"HealthData"`
+	LifeTime *struct {
+		CorrectableECCErrorCount   *int `json:"CorrectableECCErrorCount"`
+		UncorrectableECCErrorCount *int `json:"UncorrectableECCErrorCount"`
+	} `json:"LifeTime"`
+}
+
+type EnvironmentMetrics struct {
+	TemperatureCelsius *struct {
+		Reading *float64 `json:"Reading"`
+	} `json:"TemperatureCelsius"`
+	PowerWatts *struct {
+		Reading *float64 `json:"Reading"`
+	} `json:"PowerWatts"`
+	EnergykWh *struct {
+		Reading *float64 `json:"Reading"`
+	} `json:"EnergykWh"`
+}
+
+type Processor struct {
+	Id                 string `json:"Id"`
+	Name               string `json:"Name"`
+	ProcessorType      string `json:"ProcessorType"`
+	Manufacturer       string `json:"Manufacturer"`
+	Model              string `json:"Model"`
+	SerialNumber       string `json:"SerialNumber"`
+	FirmwareVersion    string `json:"FirmwareVersion"`
+	Status             Status `json:"Status"`
+	Metrics            Odata  `json:"Metrics"`
+	EnvironmentMetrics Odata  `json:"EnvironmentMetrics"`
+}
+
+func (p *Processor) IsAccelerator() bool {
+	switch p.ProcessorType {
+	case "GPU", "Accelerator", "FPGA":
+		return true
+	}
+	return false
+}
+
+type PCIeDevice struct {
+	Id              string `json:"Id"`
+	Name            string `json:"Name"`
+	Manufacturer    string `json:"Manufacturer"`
+	Model           string `json:"Model"`
+	SerialNumber    string `json:"SerialNumber"`
+	FirmwareVersion string `json:"FirmwareVersion"`
+	Status          Status `json:"Status"`
+	PCIeFunctions   Odata  `json:"PCIeFunctions"`
+	Links           struct {
+		PCIeFunctions []Odata `json:"PCIeFunctions"`
+	} `json:"Links"`
+}
+
+type PCIeFunction struct {
+	DeviceClass string `json:"DeviceClass"`
+	ClassCode   string `json:"ClassCode"`
+}
+
+// GetAcceleratorType returns the accelerator type of the function from its
+// PCI class code, which separates 3D controllers (GPUs) from ordinary VGA
+// controllers. It returns an empty string for other functions.
+func (f *PCIeFunction) GetAcceleratorType() string {
+	code := strings.TrimPrefix(strings.ToLower(f.ClassCode), "0x")
+	switch {
+	case strings.HasPrefix(code, "0302"):
+		return "GPU"
+	case strings.HasPrefix(code, "12"), f.DeviceClass == "ProcessingAccelerators":
+		return "Accelerator"
+	}
+	return ""
+}
+
+type ProcessorMetrics struct {
+	TemperatureCelsius *float64 `json:"TemperatureCelsius"`
+	ConsumedPowerWatt  *float64 `json:"ConsumedPowerWatt"`
 }
 
 type SystemResponse struct {
@@ -300,6 +602,23 @@ type SystemResponse struct {
 	} `json:"TrustedModules"`
 }
 
+type BiosResponse struct {
+	Id         string                 `json:"Id"`
+	Name       string                 `json:"Name"`
+	Attributes map[string]interface{} `json:"Attributes"`
+	Settings   *struct {
+		SettingsObject Odata `json:"SettingsObject"`
+	} `json:"@Redfish.Settings"`
+}
+
+type SecureBoot struct {
+	Id                    string `json:"Id"`
+	Name                  string `json:"Name"`
+	SecureBootCurrentBoot string `json:"SecureBootCurrentBoot"`
+	SecureBootEnable      bool   `json:"SecureBootEnable"`
+	SecureBootMode        string `json:"SecureBootMode"`
+}
+
 type PowerResponse struct {
 	Name          string             `json:"Name"`
 	Description   string             `json:"Description"`
@@ -379,21 +698,43 @@ func (psu *PowerSupplyUnit) GetOutputPower() float64 {
 	return psu.LastPowerOutputWatts
 }
 
+// LogEntry is a common structure used in any log service entry
+type LogEntry struct {
+	Id           string        `json:"Id"`
+	Name         string        `json:"Name"`
+	Created      time.Time     `json:"Created"`
+	Description  string        `json:"Description"`
+	EntryCode    xstring       `json:"EntryCode"`
+	EntryType    string        `json:"EntryType"`
+	Message      string        `json:"Message"`
+	MessageArgs  []interface{} `json:"MessageArgs"`
+	MessageId    string        `json:"MessageId"`
+	SensorNumber int           `json:"SensorNumber"`
+	SensorType   xstring       `json:"SensorType"`
+	Severity     string        `json:"Severity"`
+	Oem          *struct {
+		Dell *struct {
+			Category string `json:"Category"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+}
+
+func (e *LogEntry) GetCategory() string {
+	if e.Oem != nil && e.Oem.Dell != nil {
+		return e.Oem.Dell.Category
+	}
+	return ""
+}
+
 type IdracSelResponse struct {
-	Name        string `json:"Name"`
-	Description string `json:"Description"`
-	Members     []struct {
-		Id           string        `json:"Id"`
-		Name         string        `json:"Name"`
-		Created      time.Time     `json:"Created"`
-		Description  string        `json:"Description"`
-		EntryCode    xstring       `json:"EntryCode"`
-		EntryType    string        `json:"EntryType"`
-		Message      string        `json:"Message"`
-		MessageArgs  []interface{} `json:"MessageArgs"`
-		MessageId    string        `json:"MessageId"`
-		SensorNumber int           `json:"SensorNumber"`
-		SensorType   xstring       `json:"SensorType"`
-		Severity     string        `json:"Severity"`
-	} `json:"Members"`
+	Name        string     `json:"Name"`
+	Description string     `json:"Description"`
+	Members     []LogEntry `json:"Members"`
+}
+
+type LclogResponse struct {
+	Name        string     `json:"Name"`
+	Description string     `json:"Description"`
+	Members     []LogEntry `json:"Members"`
+	NextLink    string     `json:"Members@odata.nextLink"`
 }
@@ -0,0 +1,61 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/mrlhansen/idrac_exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	VendorDell   = "Dell"
+	VendorHPE    = "HPE"
+	VendorLenovo = "Lenovo"
+)
+
+// oemPlugin adds vendor specific metrics without touching the generic
+// refreshers. A new plugin instance is created for every client.
+type oemPlugin interface {
+	// discover is called at the end of endpoint discovery
+	discover(client *Client, root *V1Response) error
+
+	// refresh is called when the OEM metric group is collected
+	refresh(client *Client, mc *metrics.OemMetricGroup, ch chan<- prometheus.Metric) error
+}
+
+// oemStorageRefresher is implemented by plugins that report drives through
+// OEM resources, used when the standard storage resources are empty
+type oemStorageRefresher interface {
+	refreshStorage(client *Client, mc *metrics.StorageMetricGroup, ch chan<- prometheus.Metric) error
+
+	// driveSerials returns the serial numbers of the drives by id
+	driveSerials(client *Client) (map[string]string, error)
+}
+
+var oemPlugins = map[string]func() oemPlugin{
+	VendorDell:   func() oemPlugin { return &dellPlugin{} },
+	VendorHPE:    func() oemPlugin { return &hpePlugin{} },
+	VendorLenovo: func() oemPlugin { return &lenovoPlugin{} },
+}
+
+// detectVendor determines the vendor from the service root, using the OEM
+// extensions when the vendor property is missing (as on older firmware)
+func detectVendor(root *V1Response) string {
+	candidates := []string{root.Vendor}
+	for k := range root.Oem {
+		candidates = append(candidates, k)
+	}
+
+	for _, c := range candidates {
+		switch strings.ToLower(c) {
+		case "dell", "dell inc.":
+			return VendorDell
+		case "hpe", "hp":
+			return VendorHPE
+		case "lenovo":
+			return VendorLenovo
+		}
+	}
+
+	return root.Vendor
+}
@@ -0,0 +1,144 @@
+package collector
+
+import (
+	"errors"
+
+	"github.com/mrlhansen/idrac_exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const dellLicensesPath = redfishRootPath + "/Managers/iDRAC.Embedded.1/Oem/Dell/DellLicenses"
+
+// Rollup properties of DellSystem and the subsystem they are reported as
+var dellRollups = map[string]string{
+	"BatteryRollupStatus":   "batteries",
+	"CPURollupStatus":       "cpu",
+	"CoolingRollupStatus":   "cooling",
+	"CurrentRollupStatus":   "current",
+	"FanRollupStatus":       "fans",
+	"IntrusionRollupStatus": "intrusion",
+	"LicensingRollupStatus": "licensing",
+	"PSRollupStatus":        "psu",
+	"SDCardRollupStatus":    "sdcard",
+	"SELRollupStatus":       "sel",
+	"StorageRollupStatus":   "storage",
+	"SysMemPrimaryStatus":   "memory",
+	"TempRollupStatus":      "temperature",
+	"VoltRollupStatus":      "voltage",
+}
+
+type dellSystem struct {
+	Oem struct {
+		Dell *struct {
+			DellSystem map[string]interface{} `json:"DellSystem"`
+		} `json:"Dell"`
+	} `json:"Oem"`
+}
+
+type licenseService struct {
+	Licenses Odata `json:"Licenses"`
+}
+
+// dellLicense covers both the Redfish license schema and the Dell OEM one
+type dellLicense struct {
+	Id                 string `json:"Id"`
+	Description        string `json:"Description"`
+	LicenseDescription string `json:"LicenseDescription"`
+	LicenseType        string `json:"LicenseType"`
+	ExpirationDate     string `json:"ExpirationDate"`
+}
+
+func (l *dellLicense) GetDescription() string {
+	if l.LicenseDescription != "" {
+		return l.LicenseDescription
+	}
+	return l.Description
+}
+
+// dellPlugin exports the DellSystem rollup status and the installed licenses
+type dellPlugin struct {
+	licensesPath string
+}
+
+func (p *dellPlugin) discover(client *Client, root *V1Response) error {
+	var service licenseService
+	var group GroupResponse
+
+	if root.LicenseService.OdataId != "" {
+		err := client.redfishGet(root.LicenseService.OdataId, &service)
+		if err != nil {
+			return err
+		}
+
+		p.licensesPath = service.Licenses.OdataId
+		return nil
+	}
+
+	// Older firmware may only have the Dell OEM license collection, and some
+	// firmware has no license collection at all
+	err := client.redfishGet(dellLicensesPath, &group)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	p.licensesPath = dellLicensesPath
+
+	return nil
+}
+
+func (p *dellPlugin) refresh(client *Client, mc *metrics.OemMetricGroup, ch chan<- prometheus.Metric) error {
+	var system dellSystem
+	var group GroupResponse
+
+	err := client.redfishGet(client.systemPath, &system)
+	if err != nil {
+		return err
+	}
+
+	if system.Oem.Dell != nil {
+		ds := system.Oem.Dell.DellSystem
+
+		// Older firmware only links to the DellSystem resource
+		if _, ok := ds["CPURollupStatus"]; !ok {
+			if id, ok := ds["@odata.id"].(string); ok {
+				ds = nil
+				err = client.redfishGet(id, &ds)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for property, subsystem := range dellRollups {
+			status, ok := ds[property].(string)
+			if ok {
+				ch <- mc.NewDellRollupHealth(subsystem, dellHealth(status))
+			}
+		}
+	}
+
+	if p.licensesPath == "" {
+		return nil
+	}
+
+	err = client.redfishGet(p.licensesPath, &group)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range group.Members {
+		var license dellLicense
+
+		err = client.redfishGet(c.OdataId, &license)
+		if err != nil {
+			return err
+		}
+
+		ch <- mc.NewDellLicenseInfo(license.Id, license.GetDescription(), license.LicenseType, license.ExpirationDate)
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package collector
+
+import (
+	"encoding/json"
+
+	"github.com/mrlhansen/idrac_exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// hpeOem is the OEM section of an iLO system resource. iLO 4 uses the key
+// "Hp" and iLO 5 and newer use the key "Hpe".
+type hpeOem struct {
+	AggregateHealthStatus map[string]json.RawMessage `json:"AggregateHealthStatus"`
+	Links                 struct {
+		SmartStorage Odata `json:"SmartStorage"`
+	} `json:"Links"`
+}
+
+type hpeSystem struct {
+	Oem struct {
+		Hp  *hpeOem `json:"Hp"`
+		Hpe *hpeOem `json:"Hpe"`
+	} `json:"Oem"`
+}
+
+func (s *hpeSystem) getOem() *hpeOem {
+	if s.Oem.Hpe != nil {
+		return s.Oem.Hpe
+	}
+	return s.Oem.Hp
+}
+
+type hpeSmartStorage struct {
+	Links struct {
+		ArrayControllers Odata `json:"ArrayControllers"`
+	} `json:"Links"`
+}
+
+type hpeArrayController struct {
+	Id    string `json:"Id"`
+	Links struct {
+		PhysicalDrives Odata `json:"PhysicalDrives"`
+	} `json:"Links"`
+}
+
+type hpePhysicalDrive struct {
+	Id              string `json:"Id"`
+	Name            string `json:"Name"`
+	Model           string `json:"Model"`
+	SerialNumber    string `json:"SerialNumber"`
+	MediaType       string `json:"MediaType"`
+	InterfaceType   string `json:"InterfaceType"`
+	Location        string `json:"Location"`
+	CapacityMiB     int    `json:"CapacityMiB"`
+	BlockSizeBytes  *int   `json:"BlockSizeBytes"`
+	RotationalSpeed int    `json:"RotationalSpeedRpm"`
+	FirmwareVersion struct {
+		Current struct {
+			VersionString string `json:"VersionString"`
+		} `json:"Current"`
+	} `json:"FirmwareVersion"`
+	SSDEnduranceUtilizationPercentage *float64 `json:"SSDEnduranceUtilizationPercentage"`
+	Status                            Status   `json:"Status"`
+}
+
+// hpePlugin exports the aggregate health of iLO and reports drives from the
+// Smart Storage resources, which is the only place they are found on iLO 5
+type hpePlugin struct {
+	smartStoragePath string
+}
+
+func (p *hpePlugin) discover(client *Client, root *V1Response) error {
+	var system hpeSystem
+
+	err := client.redfishGet(client.systemPath, &system)
+	if err != nil {
+		return err
+	}
+
+	if oem := system.getOem(); oem != nil {
+		p.smartStoragePath = oem.Links.SmartStorage.OdataId
+	}
+
+	return nil
+}
+
+func (p *hpePlugin) refresh(client *Client, mc *metrics.OemMetricGroup, ch chan<- prometheus.Metric) error {
+	var system hpeSystem
+
+	err := client.redfishGet(client.systemPath, &system)
+	if err != nil {
+		return err
+	}
+
+	oem := system.getOem()
+	if oem == nil {
+		return nil
+	}
+
+	// Entries without a status, such as the fan redundancy, are skipped
+	for subsystem, raw := range oem.AggregateHealthStatus {
+		var entry struct {
+			Status *Status `json:"Status"`
+		}
+
+		if json.Unmarshal(raw, &entry) != nil || entry.Status == nil {
+			continue
+		}
+
+		ch <- mc.NewHpeAggregateHealth(subsystem, entry.Status.Health)
+	}
+
+	return nil
+}
+
+// walkDrives calls fn for every physical drive of the array controllers
+func (p *hpePlugin) walkDrives(client *Client, fn func(id string, d *hpePhysicalDrive)) error {
+	var storage hpeSmartStorage
+	var controllers GroupResponse
+
+	if p.smartStoragePath == "" {
+		return nil
+	}
+
+	err := client.redfishGet(p.smartStoragePath, &storage)
+	if err != nil {
+		return err
+	}
+
+	err = client.redfishGet(storage.Links.ArrayControllers.OdataId, &controllers)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range controllers.Members {
+		var controller hpeArrayController
+		var drives GroupResponse
+
+		err = client.redfishGet(c.OdataId, &controller)
+		if err != nil {
+			return err
+		}
+
+		err = client.redfishGet(controller.Links.PhysicalDrives.OdataId, &drives)
+		if err != nil {
+			return err
+		}
+
+		for _, drive := range drives.Members {
+			var d hpePhysicalDrive
+
+			err = client.redfishGet(drive.OdataId, &d)
+			if err != nil {
+				return err
+			}
+
+			// Drive ids and locations are only unique per controller
+			id := d.Location
+			if id == "" {
+				id = d.Id
+			}
+
+			fn(storageId(controller.Id, id), &d)
+		}
+	}
+
+	return nil
+}
+
+func (p *hpePlugin) refreshStorage(client *Client, mc *metrics.StorageMetricGroup, ch chan<- prometheus.Metric) error {
+	return p.walkDrives(client, func(id string, d *hpePhysicalDrive) {
+		ch <- mc.NewDriveInfo(id, d.Name, "", d.Model, d.SerialNumber, d.MediaType, d.InterfaceType, -1)
+		ch <- mc.NewDriveHealth(id, d.Status.Health)
+		ch <- mc.NewDriveCapacity(id, d.CapacityMiB*1048576)
+
+		if v := d.FirmwareVersion.Current.VersionString; v != "" {
+			ch <- mc.NewDriveFirmwareInfo(id, v)
+		}
+
+		if d.BlockSizeBytes != nil {
+			ch <- mc.NewDriveBlockSize(id, *d.BlockSizeBytes)
+		}
+
+		if d.RotationalSpeed > 0 {
+			ch <- mc.NewDriveRotationSpeed(id, d.RotationalSpeed)
+		}
+
+		if d.SSDEnduranceUtilizationPercentage != nil {
+			ch <- mc.NewDriveLifeLeft(id, 100-*d.SSDEnduranceUtilizationPercentage)
+		}
+	})
+}
+
+func (p *hpePlugin) driveSerials(client *Client) (map[string]string, error) {
+	drives := map[string]string{}
+
+	err := p.walkDrives(client, func(id string, d *hpePhysicalDrive) {
+		if d.Status.State != StateAbsent {
+			drives[id] = d.SerialNumber
+		}
+	})
+
+	return drives, err
+}
@@ -0,0 +1,91 @@
+package collector
+
+import (
+	"fmt"
+
+	"github.com/mrlhansen/idrac_exporter/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type lenovoManager struct {
+	Oem *struct {
+		Lenovo *struct {
+			FoD Odata `json:"FoD"`
+		} `json:"Lenovo"`
+	} `json:"Oem"`
+}
+
+type lenovoFoD struct {
+	Keys Odata `json:"Keys"`
+}
+
+type lenovoFoDKey struct {
+	Id          string `json:"Id"`
+	Description string `json:"Description"`
+	Expires     string `json:"Expires"`
+	Status      Status `json:"Status"`
+}
+
+// lenovoPlugin exports the Features on Demand (FoD) keys of XClarity
+type lenovoPlugin struct {
+	fodKeysPath string
+}
+
+func (p *lenovoPlugin) discover(client *Client, root *V1Response) error {
+	var group GroupResponse
+	var manager lenovoManager
+	var fod lenovoFoD
+
+	err := client.redfishGet(root.Managers.OdataId, &group)
+	if err != nil {
+		return err
+	}
+
+	if len(group.Members) == 0 {
+		return fmt.Errorf("no managers found")
+	}
+
+	err = client.redfishGet(group.Members[0].OdataId, &manager)
+	if err != nil {
+		return err
+	}
+
+	if manager.Oem == nil || manager.Oem.Lenovo == nil || manager.Oem.Lenovo.FoD.OdataId == "" {
+		return nil
+	}
+
+	err = client.redfishGet(manager.Oem.Lenovo.FoD.OdataId, &fod)
+	if err != nil {
+		return err
+	}
+
+	p.fodKeysPath = fod.Keys.OdataId
+
+	return nil
+}
+
+func (p *lenovoPlugin) refresh(client *Client, mc *metrics.OemMetricGroup, ch chan<- prometheus.Metric) error {
+	var group GroupResponse
+
+	if p.fodKeysPath == "" {
+		return nil
+	}
+
+	err := client.redfishGet(p.fodKeysPath, &group)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range group.Members {
+		var key lenovoFoDKey
+
+		err = client.redfishGet(c.OdataId, &key)
+		if err != nil {
+			return err
+		}
+
+		ch <- mc.NewLenovoFoDKeyInfo(key.Id, key.Description, key.Expires, key.Status.Health)
+	}
+
+	return nil
+}
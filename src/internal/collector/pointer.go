@@ -0,0 +1,41 @@
+package collector
+
+import (
+	"strconv"
+	"strings"
+)
+
+// jsonPointer resolves a JSON pointer (RFC 6901) in a decoded JSON document
+func jsonPointer(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" || pointer == "/" {
+		return doc, true
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			next, ok := v[token]
+			if !ok {
+				return nil, false
+			}
+			doc = next
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			doc = v[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return doc, true
+}
@@ -0,0 +1,95 @@
+package collector
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/mrlhansen/idrac_exporter/internal/logging"
+)
+
+// Minimum time between checks of the Redfish version of a target
+const versionCheckInterval = time.Minute
+
+// errNotFound is returned by redfishGet when the resource does not exist
+var errNotFound = errors.New("resource not found")
+
+// isEndpoint reports whether the path is one of the discovered endpoints
+func (client *Client) isEndpoint(path string) bool {
+	switch path {
+	case "":
+		return false
+	case client.systemPath,
+		client.chassisPath,
+		client.thermalPath,
+		client.powerPath,
+		client.environmentPath,
+		client.storagePath,
+		client.memoryPath,
+		client.processorsPath,
+		client.certServicePath,
+		client.accountsPath,
+		client.telemetryPath,
+		client.eventsPath:
+		return true
+	}
+	return false
+}
+
+// revalidate reruns the discovery when one of the discovered endpoints
+// returned 404, or when the Redfish version of the service root changed, which
+// usually means that the firmware of the BMC was upgraded
+func (client *Client) revalidate(target string) {
+	client.configMu.Lock()
+	defer client.configMu.Unlock()
+
+	if !client.foundEndpoints {
+		return
+	}
+
+	client.requestMu.Lock()
+	stale := client.stale
+	client.requestMu.Unlock()
+
+	if !stale && time.Since(client.versionChecked) >= versionCheckInterval {
+		var root V1Response
+
+		err := client.redfishGet(redfishRootPath, &root)
+		if err != nil {
+			return
+		}
+
+		client.versionChecked = time.Now()
+
+		if root.RedfishVersion != client.redfishVersion {
+			logging.Infof("Redfish version of target '%s' changed from %s to %s", target, client.redfishVersion, root.RedfishVersion)
+			stale = true
+
+			// Endpoints that were missing before the upgrade may be back
+			client.requestMu.Lock()
+			client.missing = nil
+			client.requestMu.Unlock()
+		}
+	}
+
+	if !stale {
+		return
+	}
+
+	logging.Infof("Rediscovering endpoints for target '%s'...", target)
+
+	// On failure the old endpoints are kept, stale stays set and the discovery
+	// is retried on the next scrape
+	err := client.discover()
+	if err != nil {
+		logging.Errorf(err, "Error rediscovering endpoints for target '%s'", target)
+		client.requestMu.Lock()
+		client.stale = true
+		client.requestMu.Unlock()
+		return
+	}
+
+	atomic.AddUint64(&client.rediscoveries, 1)
+	logging.Infof("Found endpoints for target '%s'", target)
+	client.start(target)
+}
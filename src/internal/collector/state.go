@@ -0,0 +1,224 @@
+package collector
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/mrlhansen/idrac_exporter/internal/logging"
+)
+
+const (
+	stateFileName     = "state.json"
+	stateSaveInterval = 5 * time.Minute
+)
+
+// endpointState holds the endpoints found during discovery
+type endpointState struct {
+	Version     string `json:"redfish_version"`
+	Vendor      string `json:"vendor"`
+	System      string `json:"system"`
+	Chassis     string `json:"chassis"`
+	Thermal     string `json:"thermal"`
+	Power       string `json:"power"`
+	Environment string `json:"environment"`
+	Storage     string `json:"storage"`
+	Memory      string `json:"memory"`
+	Processors  string `json:"processors"`
+	CertService string `json:"cert_service"`
+	Accounts    string `json:"accounts"`
+	Telemetry   string `json:"telemetry"`
+	Events      string `json:"events"`
+}
+
+type inventorySnapshot struct {
+	Components map[string]map[string]string `json:"components"`
+	Changes    map[string]uint64            `json:"changes"`
+	LastChange time.Time                    `json:"last_change"`
+}
+
+// clientState is the persisted state of a single target
+type clientState struct {
+	Endpoints    *endpointState     `json:"endpoints,omitempty"`
+	LclogCursor  time.Time          `json:"lclog_cursor"`
+	LclogEntries []LogEntry         `json:"lclog_entries,omitempty"`
+	Inventory    *inventorySnapshot `json:"inventory,omitempty"`
+}
+
+func stateFilePath() string {
+	return filepath.Join(config.Config.StateDir, stateFileName)
+}
+
+func (client *Client) saveState() clientState {
+	var state clientState
+
+	client.configMu.Lock()
+	if client.foundEndpoints {
+		state.Endpoints = &endpointState{
+			Version:     client.redfishVersion,
+			Vendor:      client.vendor,
+			System:      client.systemPath,
+			Chassis:     client.chassisPath,
+			Thermal:     client.thermalPath,
+			Power:       client.powerPath,
+			Environment: client.environmentPath,
+			Storage:     client.storagePath,
+			Memory:      client.memoryPath,
+			Processors:  client.processorsPath,
+			CertService: client.certServicePath,
+			Accounts:    client.accountsPath,
+			Telemetry:   client.telemetryPath,
+			Events:      client.eventsPath,
+		}
+	}
+	client.configMu.Unlock()
+
+	// The state is encoded after the locks are released, so everything that
+	// is modified in place by the refreshers must be copied here
+	client.lclogMu.Lock()
+	state.LclogCursor = client.lclogCursor
+	state.LclogEntries = append([]LogEntry(nil), client.lclogEntries...)
+	client.lclogMu.Unlock()
+
+	client.inventory.mu.Lock()
+	if client.inventory.components != nil {
+		state.Inventory = &inventorySnapshot{
+			Components: map[string]map[string]string{},
+			Changes:    map[string]uint64{},
+			LastChange: client.inventory.lastChange,
+		}
+		for componentType, components := range client.inventory.components {
+			c := map[string]string{}
+			for slot, serial := range components {
+				c[slot] = serial
+			}
+			state.Inventory.Components[componentType] = c
+		}
+		for componentType, count := range client.inventory.changes {
+			state.Inventory.Changes[componentType] = count
+		}
+	}
+	client.inventory.mu.Unlock()
+
+	return state
+}
+
+func (client *Client) restoreState(state *clientState) {
+	if e := state.Endpoints; e != nil {
+		client.redfishVersion = e.Version
+		client.vendor = e.Vendor
+		client.systemPath = e.System
+		client.chassisPath = e.Chassis
+		client.thermalPath = e.Thermal
+		client.powerPath = e.Power
+		client.environmentPath = e.Environment
+		client.storagePath = e.Storage
+		client.memoryPath = e.Memory
+		client.processorsPath = e.Processors
+		client.certServicePath = e.CertService
+		client.accountsPath = e.Accounts
+		client.telemetryPath = e.Telemetry
+		client.eventsPath = e.Events
+		client.foundEndpoints = true
+		client.restored = true
+	}
+
+	client.lclogCursor = state.LclogCursor
+	client.lclogEntries = state.LclogEntries
+
+	if inv := state.Inventory; inv != nil {
+		client.inventory.components = inv.Components
+		client.inventory.changes = inv.Changes
+		client.inventory.lastChange = inv.LastChange
+	}
+}
+
+// LoadState creates the clients found in the state file, so endpoints,
+// cursors and inventory survive a restart of the exporter. The periodic
+// saving of the state is started as well.
+func LoadState() {
+	if config.Config.StateDir == "" {
+		return
+	}
+
+	states := map[string]*clientState{}
+
+	data, err := os.ReadFile(stateFilePath())
+	if err == nil {
+		err = json.Unmarshal(data, &states)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		logging.Errorf(err, "Error reading state file %s", stateFilePath())
+	}
+
+	clientsMu.Lock()
+	for target, state := range states {
+		client := newClient(target)
+		client.restoreState(state)
+		clients[target] = client
+	}
+	clientsMu.Unlock()
+
+	logging.Infof("Restored state for %d targets", len(states))
+
+	go func() {
+		ticker := time.NewTicker(stateSaveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-shutdownCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			if err := SaveState(); err != nil {
+				logging.Errorf(err, "Error writing state file %s", stateFilePath())
+			}
+		}
+	}()
+}
+
+// SaveState writes the state of all clients to the state file. The file is
+// replaced atomically, so a crash never leaves a partial file behind.
+func SaveState() error {
+	if config.Config.StateDir == "" {
+		return nil
+	}
+
+	states := map[string]clientState{}
+
+	for target, client := range getClients() {
+		states[target] = client.saveState()
+	}
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(config.Config.StateDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(config.Config.StateDir, stateFileName+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), stateFilePath())
+}
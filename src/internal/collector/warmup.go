@@ -0,0 +1,86 @@
+package collector
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/mrlhansen/idrac_exporter/internal/logging"
+)
+
+// Closed when the warm-up has completed
+var warmupDone = make(chan struct{})
+
+// WarmupTargets returns the hosts listed in the configuration
+func WarmupTargets() []string {
+	var targets []string
+	for target := range config.Config.Hosts {
+		if target != "default" {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// Warmup discovers the endpoints of the given targets in the background. The
+// start of each discovery is delayed by a random amount of time within the
+// configured spread, so the targets are not all queried at once. Without
+// warm-up the exporter is ready immediately.
+func Warmup(targets []string) {
+	if !config.Config.Warmup.Enabled {
+		close(warmupDone)
+		return
+	}
+
+	spread := time.Duration(config.Config.Warmup.Spread) * time.Second
+	logging.Infof("Warming up %d targets over %s", len(targets), spread)
+
+	var wg sync.WaitGroup
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	for _, target := range targets {
+		delay := time.Duration(0)
+		if spread > 0 {
+			delay = time.Duration(rng.Int63n(int64(spread)))
+		}
+
+		wg.Add(1)
+		go func(target string, delay time.Duration) {
+			defer wg.Done()
+
+			// Outside of a request handler a panic would stop the exporter
+			defer func() {
+				if r := recover(); r != nil {
+					logging.Errorf(fmt.Errorf("%v", r), "Panic while warming up target '%s'", target)
+				}
+			}()
+
+			select {
+			case <-shutdownCtx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			// Errors are logged by GetClient and retried on the first scrape
+			GetClient(target)
+		}(target, delay)
+	}
+
+	go func() {
+		wg.Wait()
+		logging.Info("Warm-up completed")
+		close(warmupDone)
+	}()
+}
+
+// Ready reports whether the warm-up has completed
+func Ready() bool {
+	select {
+	case <-warmupDone:
+		return true
+	default:
+		return false
+	}
+}
@@ -5,14 +5,29 @@ import (
 	"os"
 	"sync"
 	"github.com/mrlhansen/idrac_exporter/internal/logging"
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
 )
 
 type HostConfig struct {
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Hostname string
-	Token    string
+	Username  string   `yaml:"username"`
+	Password  string   `yaml:"password"`
+	BootOrder []string `yaml:"boot_order"`
+	Hostname  string
+	Token     string
+}
+
+// CustomMetricConfig describes a metric read from an arbitrary Redfish path.
+// The value and labels are JSON pointers into the response, evaluated for each
+// element of the array given by Each when it is set.
+type CustomMetricConfig struct {
+	Name   string            `yaml:"name"`
+	Help   string            `yaml:"help"`
+	Type   string            `yaml:"type"`
+	Path   string            `yaml:"path"`
+	Each   string            `yaml:"each"`
+	Value  string            `yaml:"value"`
+	Labels map[string]string `yaml:"labels"`
 }
 
 type RootConfig struct {
@@ -23,16 +38,51 @@ type RootConfig struct {
 	Port          uint                   `yaml:"port"`
 	MetricsPrefix string                 `yaml:"metrics_prefix"`
 	Collect       struct {
-		System  bool `yaml:"system"`
-		Sensors bool `yaml:"sensors"`
-		SEL     bool `yaml:"sel"`
-		Power   bool `yaml:"power"`
-		Storage bool `yaml:"storage"`
-		Memory  bool `yaml:"memory"`
+		System       bool `yaml:"system"`
+		Sensors      bool `yaml:"sensors"`
+		SEL          bool `yaml:"sel"`
+		Power        bool `yaml:"power"`
+		Storage      bool `yaml:"storage"`
+		Memory       bool `yaml:"memory"`
+		Lclog        bool `yaml:"lclog"`
+		Chassis      bool `yaml:"chassis"`
+		Security     bool `yaml:"security"`
+		Boot         bool `yaml:"boot"`
+		Bios         bool `yaml:"bios"`
+		Certificates bool `yaml:"certificates"`
+		Accounts     bool `yaml:"accounts"`
+		Telemetry    bool `yaml:"telemetry"`
+		Events       bool `yaml:"events"`
+		Oem          bool `yaml:"oem"`
+		Accelerators bool `yaml:"accelerators"`
+		Inventory    bool `yaml:"inventory"`
 	} `yaml:"metrics"`
 	Timeout       uint                   `yaml:"timeout"`
 	Retries       uint                   `yaml:"retries"`
 	Hosts         map[string]*HostConfig `yaml:"hosts"`
+
+	BiosAttributes   []string `yaml:"bios_attributes"`
+	TelemetryReports []string `yaml:"telemetry_reports"`
+
+	CustomMetrics []CustomMetricConfig `yaml:"custom_metrics"`
+
+	Events struct {
+		Subscribe   bool     `yaml:"subscribe"`
+		Stream      bool     `yaml:"stream"`
+		Destination string   `yaml:"destination"`
+		Types       []string `yaml:"types"`
+		Secret      string   `yaml:"secret"`
+		BufferSize  uint     `yaml:"buffer_size"`
+	} `yaml:"events"`
+
+	PowerSampleInterval int `yaml:"power_sample_interval"`
+
+	StateDir string `yaml:"state_dir"`
+
+	Warmup struct {
+		Enabled bool `yaml:"enabled"`
+		Spread  uint `yaml:"spread"`
+	} `yaml:"warmup"`
 }
 
 func (config *RootConfig) GetHostCfg(target string) *HostConfig {
@@ -42,10 +92,11 @@ func (config *RootConfig) GetHostCfg(target string) *HostConfig {
 	hostCfg, ok := config.Hosts[target]
 	if !ok {
 		hostCfg = &HostConfig{
-			Hostname: target,
-			Username: config.Hosts["default"].Username,
-			Password: config.Hosts["default"].Password,
-			Token:    config.Hosts["default"].Token,
+			Hostname:  target,
+			Username:  config.Hosts["default"].Username,
+			Password:  config.Hosts["default"].Password,
+			Token:     config.Hosts["default"].Token,
+			BootOrder: config.Hosts["default"].BootOrder,
 		}
 		config.Hosts[target] = hostCfg
 	}
@@ -95,6 +146,60 @@ func ReadConfigFile(fileName string) {
 		Config.MetricsPrefix = "idrac"
 	}
 
+	if Config.Events.Subscribe && Config.Events.Destination == "" {
+		parseError("missing destination for", "events")
+	}
+
+	customNames := map[string]bool{}
+	for i := range Config.CustomMetrics {
+		m := &Config.CustomMetrics[i]
+		if m.Name == "" {
+			parseError("missing name for", "custom metric")
+		}
+		if !model.IsValidMetricName(model.LabelValue(Config.MetricsPrefix + "_" + m.Name)) {
+			parseError("invalid name for custom metric", m.Name)
+		}
+		if customNames[m.Name] {
+			parseError("duplicate name for custom metric", m.Name)
+		}
+		customNames[m.Name] = true
+		for k := range m.Labels {
+			if !model.LabelName(k).IsValid() {
+				parseError("invalid label name for custom metric "+m.Name, k)
+			}
+		}
+		if m.Path == "" {
+			parseError("missing path for custom metric", m.Name)
+		}
+		if m.Type == "" {
+			m.Type = "gauge"
+		}
+		if m.Type != "gauge" && m.Type != "counter" {
+			parseError("invalid type for custom metric", m.Name)
+		}
+		if m.Help == "" {
+			m.Help = "Custom metric " + m.Name
+		}
+	}
+
+	if Config.Warmup.Enabled && Config.Warmup.Spread == 0 {
+		Config.Warmup.Spread = 60
+	}
+
+	// A negative interval disables the background power sampler
+	if Config.PowerSampleInterval == 0 {
+		Config.PowerSampleInterval = 15
+	}
+
+	// Older iDRAC firmware rejects subscriptions to other event types
+	if len(Config.Events.Types) == 0 {
+		Config.Events.Types = []string{"Alert"}
+	}
+
+	if Config.Events.BufferSize == 0 {
+		Config.Events.BufferSize = 100
+	}
+
 	for k, v := range Config.Hosts {
 		if v.Username == "" {
 			parseError("missing username for host", k)
@@ -106,5 +211,10 @@ func ReadConfigFile(fileName string) {
 		data := []byte(v.Username + ":" + v.Password)
 		v.Token = base64.StdEncoding.EncodeToString(data)
 		v.Hostname = k
+
+		// Hosts with their own credentials still use the default boot order
+		if def, ok := Config.Hosts["default"]; ok && v.BootOrder == nil {
+			v.BootOrder = def.BootOrder
+		}
 	}
 }
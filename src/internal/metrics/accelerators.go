@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type AcceleratorsMetricGroup struct {
+	AcceleratorInfo        *prometheus.Desc
+	AcceleratorHealth      *prometheus.Desc
+	AcceleratorTemperature *prometheus.Desc
+	AcceleratorPower       *prometheus.Desc
+}
+
+func (metricGroup *AcceleratorsMetricGroup) GetMetricGroupType() MetricGroupType {
+	return MetricGroupTypeAccelerators
+}
+
+func (metricGroup *AcceleratorsMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Accelerators
+}
+
+func (metricGroup *AcceleratorsMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.AcceleratorInfo
+	ch <- metricGroup.AcceleratorHealth
+	ch <- metricGroup.AcceleratorTemperature
+	ch <- metricGroup.AcceleratorPower
+}
+
+func (mc *AcceleratorsMetricGroup) NewAcceleratorInfo(id, name, acceleratorType, manufacturer, model, serial, firmware string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.AcceleratorInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		firmware,
+		manufacturer,
+		model,
+		name,
+		serial,
+		acceleratorType,
+	)
+}
+
+func (mc *AcceleratorsMetricGroup) NewAcceleratorHealth(id, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.AcceleratorHealth,
+		prometheus.GaugeValue,
+		value,
+		id,
+		health,
+	)
+}
+
+func (mc *AcceleratorsMetricGroup) NewAcceleratorTemperature(id string, temperature float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.AcceleratorTemperature,
+		prometheus.GaugeValue,
+		temperature,
+		id,
+	)
+}
+
+func (mc *AcceleratorsMetricGroup) NewAcceleratorPower(id string, watts float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.AcceleratorPower,
+		prometheus.GaugeValue,
+		watts,
+		id,
+	)
+}
+
+// Instance initialization
+func NewAcceleratorsMetricGroup(prefix string) *AcceleratorsMetricGroup {
+	return &AcceleratorsMetricGroup{
+		AcceleratorInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accelerator", "info"),
+			"Information about accelerators such as GPUs and FPGAs",
+			[]string{"id", "firmware", "manufacturer", "model", "name", "serial", "type"}, nil,
+		),
+		AcceleratorHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accelerator", "health"),
+			"Health status for accelerators",
+			[]string{"id", "status"}, nil,
+		),
+		AcceleratorTemperature: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accelerator", "temperature_celsius"),
+			"Temperature of accelerators in degrees celsius",
+			[]string{"id"}, nil,
+		),
+		AcceleratorPower: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accelerator", "power_watts"),
+			"Power consumption of accelerators in watts",
+			[]string{"id"}, nil,
+		),
+	}
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"time"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type AccountsMetricGroup struct {
+	AccountsEnabled          *prometheus.Desc
+	AccountsDefaultEnabled   *prometheus.Desc
+	AccountPasswordExpiry    *prometheus.Desc
+	AccountsDirectoryService *prometheus.Desc
+}
+
+func (metricGroup *AccountsMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeAccounts
+}
+
+func (metricGroup *AccountsMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Accounts
+}
+
+func (metricGroup *AccountsMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.AccountsEnabled
+	ch <- metricGroup.AccountsDefaultEnabled
+	ch <- metricGroup.AccountPasswordExpiry
+	ch <- metricGroup.AccountsDirectoryService
+}
+
+func (mc *AccountsMetricGroup) NewAccountsEnabled(role string, count int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.AccountsEnabled,
+		prometheus.GaugeValue,
+		float64(count),
+		role,
+	)
+}
+
+func (mc *AccountsMetricGroup) NewAccountsDefaultEnabled(username string, enabled bool) prometheus.Metric {
+	var value float64
+	if enabled {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.AccountsDefaultEnabled,
+		prometheus.GaugeValue,
+		value,
+		username,
+	)
+}
+
+func (mc *AccountsMetricGroup) NewAccountPasswordExpiry(username string, expiry time.Time) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.AccountPasswordExpiry,
+		prometheus.GaugeValue,
+		float64(expiry.Unix()),
+		username,
+	)
+}
+
+func (mc *AccountsMetricGroup) NewAccountsDirectoryService(service string, enabled bool) prometheus.Metric {
+	var value float64
+	if enabled {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.AccountsDirectoryService,
+		prometheus.GaugeValue,
+		value,
+		service,
+	)
+}
+
+func NewAccountsMetricGroup(prefix string) *AccountsMetricGroup {
+    return &AccountsMetricGroup {
+		AccountsEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accounts", "enabled"),
+			"Number of enabled local accounts per role",
+			[]string{"role"}, nil,
+		),
+		AccountsDefaultEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accounts", "default_enabled"),
+			"Whether a vendor default account is enabled",
+			[]string{"username"}, nil,
+		),
+		AccountPasswordExpiry: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "account", "password_expiry_timestamp_seconds"),
+			"Password expiry time of local accounts as unix timestamp",
+			[]string{"username"}, nil,
+		),
+		AccountsDirectoryService: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "accounts", "directory_service_enabled"),
+			"Whether an external directory service is enabled for authentication",
+			[]string{"service"}, nil,
+		),
+	}
+}
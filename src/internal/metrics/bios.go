@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type BiosMetricGroup struct {
+	BiosAttribute      *prometheus.Desc
+	BiosPendingChanges *prometheus.Desc
+}
+
+func (metricGroup *BiosMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeBios
+}
+
+func (metricGroup *BiosMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Bios
+}
+
+func (metricGroup *BiosMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.BiosAttribute
+	ch <- metricGroup.BiosPendingChanges
+}
+
+func (mc *BiosMetricGroup) NewBiosAttribute(name, value string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.BiosAttribute,
+		prometheus.UntypedValue,
+		1.0,
+		name,
+		value,
+	)
+}
+
+func (mc *BiosMetricGroup) NewBiosPendingChanges(count int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.BiosPendingChanges,
+		prometheus.GaugeValue,
+		float64(count),
+	)
+}
+
+func NewBiosMetricGroup(prefix string) *BiosMetricGroup {
+    return &BiosMetricGroup {
+		BiosAttribute: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "bios", "attribute"),
+			"Current value of selected BIOS attributes",
+			[]string{"name", "value"}, nil,
+		),
+		BiosPendingChanges: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "bios", "pending_changes"),
+			"Number of BIOS attributes with changes pending until the next reboot",
+			nil, nil,
+		),
+	}
+}
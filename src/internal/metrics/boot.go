@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"strings"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type BootMetricGroup struct {
+	BootOrderInfo          *prometheus.Desc
+	BootOrderCompliant     *prometheus.Desc
+	BootSourceOverrideInfo *prometheus.Desc
+}
+
+func (metricGroup *BootMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeBoot
+}
+
+func (metricGroup *BootMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Boot
+}
+
+func (metricGroup *BootMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.BootOrderInfo
+	ch <- metricGroup.BootOrderCompliant
+	ch <- metricGroup.BootSourceOverrideInfo
+}
+
+func (mc *BootMetricGroup) NewBootOrderInfo(order []string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.BootOrderInfo,
+		prometheus.UntypedValue,
+		1.0,
+		strings.Join(order, ","),
+	)
+}
+
+func (mc *BootMetricGroup) NewBootOrderCompliant(compliant bool) prometheus.Metric {
+	var value float64
+	if compliant {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.BootOrderCompliant,
+		prometheus.GaugeValue,
+		value,
+	)
+}
+
+func (mc *BootMetricGroup) NewBootSourceOverrideInfo(enabled, target, mode string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.BootSourceOverrideInfo,
+		prometheus.UntypedValue,
+		1.0,
+		enabled,
+		mode,
+		target,
+	)
+}
+
+func NewBootMetricGroup(prefix string) *BootMetricGroup {
+    return &BootMetricGroup {
+		BootOrderInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "boot", "order_info"),
+			"Persistent boot order of the system",
+			[]string{"order"}, nil,
+		),
+		BootOrderCompliant: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "boot", "order_compliant"),
+			"Whether the boot order of the system starts with the expected boot order",
+			nil, nil,
+		),
+		BootSourceOverrideInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "boot", "source_override_info"),
+			"Boot source override settings of the system",
+			[]string{"enabled", "mode", "target"}, nil,
+		),
+	}
+}
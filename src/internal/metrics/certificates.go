@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"time"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type CertificatesMetricGroup struct {
+	CertificateExpiry *prometheus.Desc
+}
+
+func (metricGroup *CertificatesMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeCertificates
+}
+
+func (metricGroup *CertificatesMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Certificates
+}
+
+func (metricGroup *CertificatesMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.CertificateExpiry
+}
+
+func (mc *CertificatesMetricGroup) NewCertificateExpiry(subject, issuer, location string, expiry time.Time) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.CertificateExpiry,
+		prometheus.GaugeValue,
+		float64(expiry.Unix()),
+		issuer,
+		location,
+		subject,
+	)
+}
+
+func NewCertificatesMetricGroup(prefix string) *CertificatesMetricGroup {
+    return &CertificatesMetricGroup {
+		CertificateExpiry: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "certificate", "expiry_timestamp_seconds"),
+			"Expiry time of certificates as unix timestamp",
+			[]string{"issuer", "location", "subject"}, nil,
+		),
+	}
+}
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type ChassisMetricGroup struct {
+	ChassisIntrusion    *prometheus.Desc
+	ChassisLocationInfo *prometheus.Desc
+	ChassisHealth       *prometheus.Desc
+	ChassisHealthRollup *prometheus.Desc
+	ChassisPowerOn      *prometheus.Desc
+	ChassisInfo         *prometheus.Desc
+}
+
+func (metricGroup *ChassisMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeChassis
+}
+
+func (metricGroup *ChassisMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Chassis
+}
+
+func (metricGroup *ChassisMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.ChassisIntrusion
+	ch <- metricGroup.ChassisLocationInfo
+	ch <- metricGroup.ChassisHealth
+	ch <- metricGroup.ChassisHealthRollup
+	ch <- metricGroup.ChassisPowerOn
+	ch <- metricGroup.ChassisInfo
+}
+
+func (mc *ChassisMetricGroup) NewChassisIntrusion(state string) prometheus.Metric {
+	var value float64
+	switch state {
+	case "Normal":
+		value = 0
+	case "HardwareIntrusion":
+		value = 1
+	case "TamperingDetected":
+		value = 2
+	default:
+		value = 10
+	}
+	return prometheus.MustNewConstMetric(
+		mc.ChassisIntrusion,
+		prometheus.GaugeValue,
+		value,
+		state,
+	)
+}
+
+func (mc *ChassisMetricGroup) NewChassisLocationInfo(rack, row, building, room string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.ChassisLocationInfo,
+		prometheus.UntypedValue,
+		1.0,
+		building,
+		rack,
+		room,
+		row,
+	)
+}
+
+func (mc *ChassisMetricGroup) NewChassisHealth(health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.ChassisHealth,
+		prometheus.GaugeValue,
+		value,
+		health,
+	)
+}
+
+func (mc *ChassisMetricGroup) NewChassisHealthRollup(health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.ChassisHealthRollup,
+		prometheus.GaugeValue,
+		value,
+		health,
+	)
+}
+
+func (mc *ChassisMetricGroup) NewChassisPowerOn(state string) prometheus.Metric {
+	var value float64
+	if state == "On" {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.ChassisPowerOn,
+		prometheus.GaugeValue,
+		value,
+	)
+}
+
+func (mc *ChassisMetricGroup) NewChassisInfo(assetTag, chassisType, environmentalClass, manufacturer, model, partNumber, serial, sku string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.ChassisInfo,
+		prometheus.UntypedValue,
+		1.0,
+		assetTag,
+		chassisType,
+		environmentalClass,
+		manufacturer,
+		model,
+		partNumber,
+		serial,
+		sku,
+	)
+}
+
+func NewChassisMetricGroup(prefix string) *ChassisMetricGroup {
+    return &ChassisMetricGroup {
+		ChassisIntrusion: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "chassis", "intrusion"),
+			"Physical intrusion sensor of the chassis (0 = normal, 1 = intrusion, 2 = tampering)",
+			[]string{"state"}, nil,
+		),
+		ChassisLocationInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "chassis", "location_info"),
+			"Physical location of the chassis",
+			[]string{"building", "rack", "room", "row"}, nil,
+		),
+		ChassisHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "chassis", "health"),
+			"Health status of the chassis",
+			[]string{"status"}, nil,
+		),
+		ChassisHealthRollup: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "chassis", "health_rollup"),
+			"Health status of the chassis and all its dependent resources",
+			[]string{"status"}, nil,
+		),
+		ChassisPowerOn: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "chassis", "power_on"),
+			"Power state of the chassis",
+			nil, nil,
+		),
+		ChassisInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "chassis", "info"),
+			"Information about the chassis",
+			[]string{"asset_tag", "chassis_type", "environmental_class", "manufacturer", "model", "part_number", "serial", "sku"}, nil,
+		),
+	}
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"sort"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type CustomMetric struct {
+	Config    config.CustomMetricConfig
+	Desc      *prometheus.Desc
+	LabelKeys []string
+}
+
+type CustomMetricGroup struct {
+	Metrics []*CustomMetric
+}
+
+func (metricGroup *CustomMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeCustom
+}
+
+func (metricGroup *CustomMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return len(config.CustomMetrics) > 0
+}
+
+func (metricGroup *CustomMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range metricGroup.Metrics {
+		ch <- m.Desc
+	}
+}
+
+func (mc *CustomMetricGroup) NewCustomMetric(m *CustomMetric, value float64, labels []string) prometheus.Metric {
+	valueType := prometheus.GaugeValue
+	if m.Config.Type == "counter" {
+		valueType = prometheus.CounterValue
+	}
+	return prometheus.MustNewConstMetric(
+		m.Desc,
+		valueType,
+		value,
+		labels...,
+	)
+}
+
+// Instance initialization
+func NewCustomMetricGroup(prefix string, metrics []config.CustomMetricConfig) *CustomMetricGroup {
+	group := &CustomMetricGroup{}
+
+	for _, c := range metrics {
+		keys := make([]string, 0, len(c.Labels))
+		for k := range c.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		group.Metrics = append(group.Metrics, &CustomMetric{
+			Config:    c,
+			LabelKeys: keys,
+			Desc: prometheus.NewDesc(
+				prometheus.BuildFQName(prefix, "", c.Name),
+				c.Help,
+				keys, nil,
+			),
+		})
+	}
+
+	return group
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"time"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+
+type EventsMetricGroup struct {
+    EventsTotal     *prometheus.Desc
+    EventEntry      *prometheus.Desc
+    EventsStreamConnected *prometheus.Desc
+    EventsResourceHealth  *prometheus.Desc
+}
+
+func (metricGroup *EventsMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeEvents
+}
+
+func (metricGroup *EventsMetricGroup) IsEnabled(config *config.RootConfig) bool {
+    return config.Collect.Events
+}
+
+func (metricGroup *EventsMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+    ch <- metricGroup.EventsTotal
+    ch <- metricGroup.EventEntry
+    ch <- metricGroup.EventsStreamConnected
+    ch <- metricGroup.EventsResourceHealth
+}
+
+func (mc *EventsMetricGroup) NewEventsTotal(target, severity, messageId string, count uint64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.EventsTotal,
+		prometheus.CounterValue,
+		float64(count),
+		messageId,
+		severity,
+		target,
+	)
+}
+
+func (mc *EventsMetricGroup) NewEventEntry(id, message, messageId, severity string, created time.Time) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.EventEntry,
+		prometheus.CounterValue,
+		float64(created.Unix()),
+		id,
+		message,
+		messageId,
+		severity,
+	)
+}
+func (mc *EventsMetricGroup) NewEventsStreamConnected(connected bool) prometheus.Metric {
+	var value float64
+	if connected {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.EventsStreamConnected,
+		prometheus.GaugeValue,
+		value,
+	)
+}
+
+func (mc *EventsMetricGroup) NewEventsResourceHealth(resource, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.EventsResourceHealth,
+		prometheus.GaugeValue,
+		value,
+		resource,
+		health,
+	)
+}
+
+// Instance initialization
+func NewEventsMetricGroup(prefix string) *EventsMetricGroup {
+    return &EventsMetricGroup {
+		EventsTotal: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "events", "total"),
+			"Total number of events received from the Redfish event service",
+			[]string{"message_id", "severity", "target"}, nil,
+		),
+		EventEntry: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "event", "entry"),
+			"Recent event received from the Redfish event service",
+			[]string{"id", "message", "message_id", "severity"}, nil,
+		),
+		EventsStreamConnected: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "events", "stream_connected"),
+			"Whether the server-sent event stream of the target is connected",
+			nil, nil,
+		),
+		EventsResourceHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "events", "resource_health"),
+			"Health status of resources according to the severity of their latest event",
+			[]string{"resource", "status"}, nil,
+		),
+	}
+}
@@ -11,4 +11,18 @@ func health2value(health string) float64 {
 		return 2
 	}
 	return 10
+}
+
+func volumestate2value(state string) float64 {
+	switch state {
+	case "Optimal":
+		return 0
+	case "Rebuilding":
+		return 1
+	case "Degraded":
+		return 2
+	case "Failed":
+		return 3
+	}
+	return 10
 }
\ No newline at end of file
@@ -15,6 +15,19 @@ const (
 	MetricGroupTypeIdracSel
 	MetricGroupTypeStorage
 	MetricGroupTypeMemory
+	MetricGroupTypeLclog
+	MetricGroupTypeChassis
+	MetricGroupTypeSecurity
+	MetricGroupTypeBoot
+	MetricGroupTypeBios
+	MetricGroupTypeCertificates
+	MetricGroupTypeAccounts
+	MetricGroupTypeTelemetry
+	MetricGroupTypeEvents
+	MetricGroupTypeCustom
+	MetricGroupTypeOem
+	MetricGroupTypeAccelerators
+	MetricGroupTypeInventory
 )
 
 type MetricGroup interface {
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type InventoryMetricGroup struct {
+	InventoryChanges    *prometheus.Desc
+	InventoryLastChange *prometheus.Desc
+}
+
+func (metricGroup *InventoryMetricGroup) GetMetricGroupType() MetricGroupType {
+	return MetricGroupTypeInventory
+}
+
+func (metricGroup *InventoryMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Inventory
+}
+
+func (metricGroup *InventoryMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.InventoryChanges
+	ch <- metricGroup.InventoryLastChange
+}
+
+func (mc *InventoryMetricGroup) NewInventoryChanges(componentType string, count uint64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.InventoryChanges,
+		prometheus.CounterValue,
+		float64(count),
+		componentType,
+	)
+}
+
+func (mc *InventoryMetricGroup) NewInventoryLastChange(timestamp int64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.InventoryLastChange,
+		prometheus.GaugeValue,
+		float64(timestamp),
+	)
+}
+
+// Instance initialization
+func NewInventoryMetricGroup(prefix string) *InventoryMetricGroup {
+	return &InventoryMetricGroup{
+		InventoryChanges: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "inventory", "changes_total"),
+			"Total number of components that were replaced, added or removed",
+			[]string{"component_type"}, nil,
+		),
+		InventoryLastChange: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "inventory", "last_change_timestamp_seconds"),
+			"Timestamp of the last inventory change in seconds since epoch",
+			nil, nil,
+		),
+	}
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"time"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+
+type LclogMetricGroup struct {
+    LclogEntry      *prometheus.Desc
+}
+
+func (metricGroup *LclogMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeLclog
+}
+
+func (metricGroup *LclogMetricGroup) IsEnabled(config *config.RootConfig) bool {
+    return config.Collect.Lclog
+}
+
+func (metricGroup *LclogMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+    ch <- metricGroup.LclogEntry
+}
+
+func (mc *LclogMetricGroup) NewLclogEntry(id, message, messageId, category, severity string, created time.Time) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.LclogEntry,
+		prometheus.CounterValue,
+		float64(created.Unix()),
+		id,
+		message,
+		messageId,
+		category,
+		severity,
+	)
+}
+
+// Instance initialization
+func NewLclogMetricGroup(prefix string) *LclogMetricGroup {
+    return &LclogMetricGroup {
+		LclogEntry: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "lclog", "entry"),
+			"Entry from the lifecycle controller log",
+			[]string{"id", "message", "message_id", "category", "severity"}, nil,
+		),
+	}
+}
@@ -14,6 +14,10 @@ type MemoryMetricGroup struct {
     MemoryModuleHealth        *prometheus.Desc
     MemoryModuleCapacity      *prometheus.Desc
     MemoryModuleSpeed         *prometheus.Desc
+    MemoryModuleCorrectableErrors   *prometheus.Desc
+    MemoryModuleUncorrectableErrors *prometheus.Desc
+    MemoryModuleAlarmTrip           *prometheus.Desc
+    MemoryModuleTemperature         *prometheus.Desc
 }
 
 func (metricGroup *MemoryMetricGroup) GetMetricGroupType() MetricGroupType {
@@ -29,6 +33,10 @@ func (metricGroup *MemoryMetricGroup) Describe(ch chan<- *prometheus.Desc) {
     ch <- metricGroup.MemoryModuleHealth
     ch <- metricGroup.MemoryModuleCapacity
     ch <- metricGroup.MemoryModuleSpeed
+    ch <- metricGroup.MemoryModuleCorrectableErrors
+    ch <- metricGroup.MemoryModuleUncorrectableErrors
+    ch <- metricGroup.MemoryModuleAlarmTrip
+    ch <- metricGroup.MemoryModuleTemperature
 }
 
 
@@ -75,6 +83,46 @@ func (mc *MemoryMetricGroup) NewMemoryModuleSpeed(id string, speed int) promethe
 		id,
 	)
 }
+func (mc *MemoryMetricGroup) NewMemoryModuleCorrectableErrors(id string, count int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.MemoryModuleCorrectableErrors,
+		prometheus.CounterValue,
+		float64(count),
+		id,
+	)
+}
+
+func (mc *MemoryMetricGroup) NewMemoryModuleUncorrectableErrors(id string, count int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.MemoryModuleUncorrectableErrors,
+		prometheus.CounterValue,
+		float64(count),
+		id,
+	)
+}
+
+func (mc *MemoryMetricGroup) NewMemoryModuleAlarmTrip(id, alarm string, tripped bool) prometheus.Metric {
+	var value float64
+	if tripped {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.MemoryModuleAlarmTrip,
+		prometheus.GaugeValue,
+		value,
+		id,
+		alarm,
+	)
+}
+
+func (mc *MemoryMetricGroup) NewMemoryModuleTemperature(id string, temperature float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.MemoryModuleTemperature,
+		prometheus.GaugeValue,
+		temperature,
+		id,
+	)
+}
 
 // Instance initialization
 func NewMemoryMetricGroup(prefix string) *MemoryMetricGroup {
@@ -99,6 +147,26 @@ func NewMemoryMetricGroup(prefix string) *MemoryMetricGroup {
 			"Speed of memory modules in Mhz",
 			[]string{"id"}, nil,
 		),
+		MemoryModuleCorrectableErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "memory_module", "correctable_errors_total"),
+			"Total number of correctable ECC errors for memory modules",
+			[]string{"id"}, nil,
+		),
+		MemoryModuleUncorrectableErrors: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "memory_module", "uncorrectable_errors_total"),
+			"Total number of uncorrectable ECC errors for memory modules",
+			[]string{"id"}, nil,
+		),
+		MemoryModuleAlarmTrip: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "memory_module", "alarm_trip"),
+			"Alarm trips for memory modules",
+			[]string{"id", "alarm"}, nil,
+		),
+		MemoryModuleTemperature: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "memory_module", "temperature_celsius"),
+			"Temperature of memory modules in degrees celsius",
+			[]string{"id"}, nil,
+		),
 	}
 }
 
@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// OemMetricGroup contains the vendor specific metrics, which are collected by
+// the vendor plugin matching the target
+type OemMetricGroup struct {
+	OemVendorInfo      *prometheus.Desc
+	DellRollupHealth   *prometheus.Desc
+	DellLicenseInfo    *prometheus.Desc
+	HpeAggregateHealth *prometheus.Desc
+	LenovoFoDKeyInfo   *prometheus.Desc
+}
+
+func (metricGroup *OemMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeOem
+}
+
+func (metricGroup *OemMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Oem
+}
+
+func (metricGroup *OemMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.OemVendorInfo
+	ch <- metricGroup.DellRollupHealth
+	ch <- metricGroup.DellLicenseInfo
+	ch <- metricGroup.HpeAggregateHealth
+	ch <- metricGroup.LenovoFoDKeyInfo
+}
+
+func (mc *OemMetricGroup) NewOemVendorInfo(vendor string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.OemVendorInfo,
+		prometheus.UntypedValue,
+		1.0,
+		vendor,
+	)
+}
+
+func (mc *OemMetricGroup) NewDellRollupHealth(subsystem, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.DellRollupHealth,
+		prometheus.GaugeValue,
+		value,
+		health,
+		subsystem,
+	)
+}
+
+func (mc *OemMetricGroup) NewDellLicenseInfo(id, description, licenseType, expiry string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DellLicenseInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		description,
+		expiry,
+		licenseType,
+	)
+}
+
+func (mc *OemMetricGroup) NewHpeAggregateHealth(subsystem, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.HpeAggregateHealth,
+		prometheus.GaugeValue,
+		value,
+		health,
+		subsystem,
+	)
+}
+
+func (mc *OemMetricGroup) NewLenovoFoDKeyInfo(id, description, expiry, health string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.LenovoFoDKeyInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		description,
+		expiry,
+		health,
+	)
+}
+
+func NewOemMetricGroup(prefix string) *OemMetricGroup {
+    return &OemMetricGroup {
+		OemVendorInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "oem", "vendor_info"),
+			"Vendor detected from the Redfish service root",
+			[]string{"vendor"}, nil,
+		),
+		DellRollupHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "dell", "rollup_health"),
+			"Rollup health status of iDRAC subsystems",
+			[]string{"status", "subsystem"}, nil,
+		),
+		DellLicenseInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "dell", "license_info"),
+			"Information about iDRAC licenses",
+			[]string{"id", "description", "expiry", "type"}, nil,
+		),
+		HpeAggregateHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "hpe", "aggregate_health"),
+			"Aggregate health status of iLO subsystems",
+			[]string{"status", "subsystem"}, nil,
+		),
+		LenovoFoDKeyInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "lenovo", "fod_key_info"),
+			"Information about Lenovo Features on Demand keys",
+			[]string{"id", "description", "expiry", "status"}, nil,
+		),
+	}
+}
@@ -17,6 +17,7 @@ type PowerMetricGroup struct {
     PowerControlMaxConsumedWatts *prometheus.Desc
     PowerControlAvgConsumedWatts *prometheus.Desc
     PowerControlInterval        *prometheus.Desc
+    PowerControlEnergy          *prometheus.Desc
 }
 
 func (metricGroup *PowerMetricGroup) GetMetricGroupType() MetricGroupType {
@@ -39,6 +40,7 @@ func (metricGroup *PowerMetricGroup) Describe(ch chan<- *prometheus.Desc) {
     ch <- metricGroup.PowerControlMaxConsumedWatts
     ch <- metricGroup.PowerControlAvgConsumedWatts
     ch <- metricGroup.PowerControlInterval
+    ch <- metricGroup.PowerControlEnergy
 }
 
 func (mc *PowerMetricGroup) NewPowerSupplyInputWatts(value float64, id string) prometheus.Metric {
@@ -146,6 +148,14 @@ func (mc *PowerMetricGroup) NewPowerControlInterval(interval int, id, name strin
 	)
 }
 
+func (mc *PowerMetricGroup) NewPowerControlEnergy(joules float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.PowerControlEnergy,
+		prometheus.CounterValue,
+		joules,
+	)
+}
+
 // Instance initialization
 func NewPowerMetricGroup(prefix string) *PowerMetricGroup {
     return &PowerMetricGroup {
@@ -204,5 +214,10 @@ func NewPowerMetricGroup(prefix string) *PowerMetricGroup {
 			"Interval for measurements of power control system",
 			[]string{"id", "name"}, nil,
 		),
+		PowerControlEnergy: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "power_control", "energy_joules_total"),
+			"Total energy consumed by the system in joules",
+			nil, nil,
+		),
 	}
 }
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type SecurityMetricGroup struct {
+	SecureBootEnabled   *prometheus.Desc
+	TpmPresent          *prometheus.Desc
+	TpmInfo             *prometheus.Desc
+	HostWatchdogEnabled *prometheus.Desc
+}
+
+func (metricGroup *SecurityMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeSecurity
+}
+
+func (metricGroup *SecurityMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Security
+}
+
+func (metricGroup *SecurityMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.SecureBootEnabled
+	ch <- metricGroup.TpmPresent
+	ch <- metricGroup.TpmInfo
+	ch <- metricGroup.HostWatchdogEnabled
+}
+
+func (mc *SecurityMetricGroup) NewSecureBootEnabled(enabled bool, mode, currentBoot string) prometheus.Metric {
+	var value float64
+	if enabled {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.SecureBootEnabled,
+		prometheus.GaugeValue,
+		value,
+		currentBoot,
+		mode,
+	)
+}
+
+func (mc *SecurityMetricGroup) NewTpmPresent(present bool) prometheus.Metric {
+	var value float64
+	if present {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.TpmPresent,
+		prometheus.GaugeValue,
+		value,
+	)
+}
+
+func (mc *SecurityMetricGroup) NewTpmInfo(id, interfaceType, firmware, state string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.TpmInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		firmware,
+		interfaceType,
+		state,
+	)
+}
+
+func (mc *SecurityMetricGroup) NewHostWatchdogEnabled(enabled bool, timeoutAction string) prometheus.Metric {
+	var value float64
+	if enabled {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.HostWatchdogEnabled,
+		prometheus.GaugeValue,
+		value,
+		timeoutAction,
+	)
+}
+
+func NewSecurityMetricGroup(prefix string) *SecurityMetricGroup {
+    return &SecurityMetricGroup {
+		SecureBootEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "security", "secure_boot_enabled"),
+			"Secure Boot state of the system",
+			[]string{"current_boot", "mode"}, nil,
+		),
+		TpmPresent: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "security", "tpm_present"),
+			"Presence of a trusted platform module in the system",
+			nil, nil,
+		),
+		TpmInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "security", "tpm_info"),
+			"Information about trusted platform modules",
+			[]string{"id", "firmware", "interface", "state"}, nil,
+		),
+		HostWatchdogEnabled: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "security", "host_watchdog_enabled"),
+			"Host watchdog timer state of the system",
+			[]string{"timeout_action"}, nil,
+		),
+	}
+}
@@ -10,8 +10,26 @@ import (
 
 type StorageMetricGroup struct {
     DriveInfo     *prometheus.Desc
+    DriveFirmwareInfo       *prometheus.Desc
     DriveHealth   *prometheus.Desc
     DriveCapacity *prometheus.Desc
+    DriveBlockSize          *prometheus.Desc
+    DriveRotationSpeed      *prometheus.Desc
+    DriveCapableSpeed       *prometheus.Desc
+    DriveNegotiatedSpeed    *prometheus.Desc
+    DriveLifeLeft           *prometheus.Desc
+    DriveFailurePredicted   *prometheus.Desc
+    DriveHotspare           *prometheus.Desc
+    ControllerInfo          *prometheus.Desc
+    ControllerHealth        *prometheus.Desc
+    ControllerSpeed         *prometheus.Desc
+    ControllerBatteryHealth *prometheus.Desc
+    VolumeInfo              *prometheus.Desc
+    VolumeHealth            *prometheus.Desc
+    VolumeState             *prometheus.Desc
+    VolumeCapacity          *prometheus.Desc
+    VolumeStripeSize        *prometheus.Desc
+    VolumeOperationPercent  *prometheus.Desc
 }
 
 func (metricGroup *StorageMetricGroup) GetMetricGroupType() MetricGroupType {
@@ -23,8 +41,26 @@ func (metricGroup *StorageMetricGroup) IsEnabled(config *config.RootConfig) bool
 
 func (metricGroup *StorageMetricGroup) Describe(ch chan<- *prometheus.Desc) {
     ch <- metricGroup.DriveInfo
+    ch <- metricGroup.DriveFirmwareInfo
     ch <- metricGroup.DriveHealth
     ch <- metricGroup.DriveCapacity
+    ch <- metricGroup.DriveBlockSize
+    ch <- metricGroup.DriveRotationSpeed
+    ch <- metricGroup.DriveCapableSpeed
+    ch <- metricGroup.DriveNegotiatedSpeed
+    ch <- metricGroup.DriveLifeLeft
+    ch <- metricGroup.DriveFailurePredicted
+    ch <- metricGroup.DriveHotspare
+    ch <- metricGroup.ControllerInfo
+    ch <- metricGroup.ControllerHealth
+    ch <- metricGroup.ControllerSpeed
+    ch <- metricGroup.ControllerBatteryHealth
+    ch <- metricGroup.VolumeInfo
+    ch <- metricGroup.VolumeHealth
+    ch <- metricGroup.VolumeState
+    ch <- metricGroup.VolumeCapacity
+    ch <- metricGroup.VolumeStripeSize
+    ch <- metricGroup.VolumeOperationPercent
 }
 
 func (mc *StorageMetricGroup) NewDriveInfo(id, name, manufacturer, model, serial, mediatype, protocol string, slot int) prometheus.Metric {
@@ -51,6 +87,16 @@ func (mc *StorageMetricGroup) NewDriveInfo(id, name, manufacturer, model, serial
 	)
 }
 
+func (mc *StorageMetricGroup) NewDriveFirmwareInfo(id, revision string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DriveFirmwareInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		revision,
+	)
+}
+
 func (mc *StorageMetricGroup) NewDriveHealth(id, health string) prometheus.Metric {
 	value := health2value(health)
 	return prometheus.MustNewConstMetric(
@@ -70,7 +116,185 @@ func (mc *StorageMetricGroup) NewDriveCapacity(id string, capacity int) promethe
 		id,
 	)
 }
+func (mc *StorageMetricGroup) NewDriveBlockSize(id string, size int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DriveBlockSize,
+		prometheus.GaugeValue,
+		float64(size),
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewDriveRotationSpeed(id string, rpm int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DriveRotationSpeed,
+		prometheus.GaugeValue,
+		float64(rpm),
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewDriveCapableSpeed(id string, speed float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DriveCapableSpeed,
+		prometheus.GaugeValue,
+		speed,
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewDriveNegotiatedSpeed(id string, speed float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DriveNegotiatedSpeed,
+		prometheus.GaugeValue,
+		speed,
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewDriveLifeLeft(id string, percent float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.DriveLifeLeft,
+		prometheus.GaugeValue,
+		percent,
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewDriveFailurePredicted(id string, predicted bool) prometheus.Metric {
+	var value float64
+	if predicted {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.DriveFailurePredicted,
+		prometheus.GaugeValue,
+		value,
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewDriveHotspare(id, hotspare string) prometheus.Metric {
+	var value float64
+	if hotspare != "" && hotspare != "None" {
+		value = 1
+	}
+	return prometheus.MustNewConstMetric(
+		mc.DriveHotspare,
+		prometheus.GaugeValue,
+		value,
+		id,
+		hotspare,
+	)
+}
+
+func (mc *StorageMetricGroup) NewControllerInfo(id, name, manufacturer, model, firmware string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.ControllerInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		firmware,
+		manufacturer,
+		model,
+		name,
+	)
+}
+
+func (mc *StorageMetricGroup) NewControllerHealth(id, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.ControllerHealth,
+		prometheus.GaugeValue,
+		value,
+		id,
+		health,
+	)
+}
+
+func (mc *StorageMetricGroup) NewControllerSpeed(id string, speed float64) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.ControllerSpeed,
+		prometheus.GaugeValue,
+		speed,
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewControllerBatteryHealth(id, name, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.ControllerBatteryHealth,
+		prometheus.GaugeValue,
+		value,
+		id,
+		name,
+		health,
+	)
+}
+
+func (mc *StorageMetricGroup) NewVolumeInfo(id, name, raidtype, volumetype string, encrypted bool) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.VolumeInfo,
+		prometheus.UntypedValue,
+		1.0,
+		id,
+		fmt.Sprint(encrypted),
+		name,
+		raidtype,
+		volumetype,
+	)
+}
+
+func (mc *StorageMetricGroup) NewVolumeHealth(id, health string) prometheus.Metric {
+	value := health2value(health)
+	return prometheus.MustNewConstMetric(
+		mc.VolumeHealth,
+		prometheus.GaugeValue,
+		value,
+		id,
+		health,
+	)
+}
+
+func (mc *StorageMetricGroup) NewVolumeState(id, state string) prometheus.Metric {
+	value := volumestate2value(state)
+	return prometheus.MustNewConstMetric(
+		mc.VolumeState,
+		prometheus.GaugeValue,
+		value,
+		id,
+		state,
+	)
+}
+
+func (mc *StorageMetricGroup) NewVolumeCapacity(id string, capacity int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.VolumeCapacity,
+		prometheus.GaugeValue,
+		float64(capacity),
+		id,
+	)
+}
 
+func (mc *StorageMetricGroup) NewVolumeStripeSize(id string, size int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.VolumeStripeSize,
+		prometheus.GaugeValue,
+		float64(size),
+		id,
+	)
+}
+
+func (mc *StorageMetricGroup) NewVolumeOperationPercent(id, operation string, percent int) prometheus.Metric {
+	return prometheus.MustNewConstMetric(
+		mc.VolumeOperationPercent,
+		prometheus.GaugeValue,
+		float64(percent),
+		id,
+		operation,
+	)
+}
 
 // Instance initialization
 func NewStorageMetricGroup(prefix string) *StorageMetricGroup {
@@ -80,6 +304,11 @@ func NewStorageMetricGroup(prefix string) *StorageMetricGroup {
 			"Information about disk drives",
 			[]string{"id", "manufacturer", "mediatype", "model", "name", "protocol", "serial", "slot"}, nil,
 		),
+		DriveFirmwareInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "firmware_info"),
+			"Firmware revision of disk drives",
+			[]string{"id", "revision"}, nil,
+		),
 		DriveHealth: prometheus.NewDesc(
 			prometheus.BuildFQName(prefix, "drive", "health"),
 			"Health status for disk drives",
@@ -90,5 +319,90 @@ func NewStorageMetricGroup(prefix string) *StorageMetricGroup {
 			"Capacity of disk drives in bytes",
 			[]string{"id"}, nil,
 		),
+		DriveBlockSize: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "block_size_bytes"),
+			"Block size of disk drives in bytes",
+			[]string{"id"}, nil,
+		),
+		DriveRotationSpeed: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "rotation_speed_rpm"),
+			"Rotation speed of disk drives in RPM",
+			[]string{"id"}, nil,
+		),
+		DriveCapableSpeed: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "capable_speed_gbps"),
+			"Maximum link speed supported by disk drives in Gbps",
+			[]string{"id"}, nil,
+		),
+		DriveNegotiatedSpeed: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "negotiated_speed_gbps"),
+			"Negotiated link speed of disk drives in Gbps",
+			[]string{"id"}, nil,
+		),
+		DriveLifeLeft: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "life_left_percent"),
+			"Predicted media life left of disk drives in percentage",
+			[]string{"id"}, nil,
+		),
+		DriveFailurePredicted: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "failure_predicted"),
+			"Predictive failure state of disk drives",
+			[]string{"id"}, nil,
+		),
+		DriveHotspare: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "drive", "hotspare"),
+			"Hot spare state of disk drives",
+			[]string{"id", "type"}, nil,
+		),
+		ControllerInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_controller", "info"),
+			"Information about storage controllers",
+			[]string{"id", "firmware", "manufacturer", "model", "name"}, nil,
+		),
+		ControllerHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_controller", "health"),
+			"Health status for storage controllers",
+			[]string{"id", "status"}, nil,
+		),
+		ControllerSpeed: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_controller", "speed_gbps"),
+			"Speed of storage controllers in Gbps",
+			[]string{"id"}, nil,
+		),
+		ControllerBatteryHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_controller", "battery_health"),
+			"Health status for storage controller cache batteries",
+			[]string{"id", "name", "status"}, nil,
+		),
+		VolumeInfo: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_volume", "info"),
+			"Information about virtual disks",
+			[]string{"id", "encrypted", "name", "raidtype", "volumetype"}, nil,
+		),
+		VolumeHealth: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_volume", "health"),
+			"Health status for virtual disks",
+			[]string{"id", "status"}, nil,
+		),
+		VolumeState: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_volume", "state"),
+			"State of virtual disks (0 = optimal, 1 = rebuilding, 2 = degraded, 3 = failed)",
+			[]string{"id", "state"}, nil,
+		),
+		VolumeCapacity: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_volume", "capacity_bytes"),
+			"Capacity of virtual disks in bytes",
+			[]string{"id"}, nil,
+		),
+		VolumeStripeSize: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_volume", "stripe_size_bytes"),
+			"Stripe size of virtual disks in bytes",
+			[]string{"id"}, nil,
+		),
+		VolumeOperationPercent: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "storage_volume", "operation_percent"),
+			"Progress of operations running on virtual disks, such as rebuild or consistency check",
+			[]string{"id", "operation"}, nil,
+		),
 	}
 }
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"time"
+	"github.com/mrlhansen/idrac_exporter/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type TelemetryMetricGroup struct {
+	TelemetryMetricValue *prometheus.Desc
+}
+
+func (metricGroup *TelemetryMetricGroup) GetMetricGroupType() MetricGroupType {
+    return MetricGroupTypeTelemetry
+}
+
+func (metricGroup *TelemetryMetricGroup) IsEnabled(config *config.RootConfig) bool {
+	return config.Collect.Telemetry
+}
+
+func (metricGroup *TelemetryMetricGroup) Describe(ch chan<- *prometheus.Desc) {
+	ch <- metricGroup.TelemetryMetricValue
+}
+
+func (mc *TelemetryMetricGroup) NewTelemetryMetricValue(report, metric, property string, value float64, timestamp time.Time) prometheus.Metric {
+	m := prometheus.MustNewConstMetric(
+		mc.TelemetryMetricValue,
+		prometheus.GaugeValue,
+		value,
+		metric,
+		property,
+		report,
+	)
+	if timestamp.IsZero() {
+		return m
+	}
+	return prometheus.NewMetricWithTimestamp(timestamp, m)
+}
+
+func NewTelemetryMetricGroup(prefix string) *TelemetryMetricGroup {
+    return &TelemetryMetricGroup {
+		TelemetryMetricValue: prometheus.NewDesc(
+			prometheus.BuildFQName(prefix, "telemetry", "metric_value"),
+			"Latest value of metrics from telemetry service metric reports",
+			[]string{"metric", "property", "report"}, nil,
+		),
+	}
+}